@@ -17,10 +17,38 @@ func (a AstPrinter) VisitBinaryExpr(binary expr.Binary) (interface{}, error) {
 	return a.paranthesize(binary.Operator.Lexeme, binary.Left, binary.Right), nil
 }
 
+func (a AstPrinter) VisitCallExpr(call expr.Call) (interface{}, error) {
+	return a.paranthesize("call", append([]expr.Expr{call.Callee}, call.Arguments...)...), nil
+}
+
+func (a AstPrinter) VisitConditionalExpr(conditional expr.Conditional) (interface{}, error) {
+	return a.paranthesize("?:", conditional.Condition, conditional.ThenBranch, conditional.ElseBranch), nil
+}
+
+func (a AstPrinter) VisitGetExpr(get expr.Get) (interface{}, error) {
+	return a.paranthesize("."+get.Name.Lexeme, get.Object), nil
+}
+
 func (a AstPrinter) VisitGroupingExpr(grouping expr.Grouping) (interface{}, error) {
 	return a.paranthesize("group", grouping.Expression), nil
 }
 
+func (a AstPrinter) VisitIndexExpr(index expr.Index) (interface{}, error) {
+	return a.paranthesize("[]", index.Object, index.Index), nil
+}
+
+func (a AstPrinter) VisitIndexSetExpr(indexSet expr.IndexSet) (interface{}, error) {
+	return a.paranthesize("[]=", indexSet.Object, indexSet.Index, indexSet.Value), nil
+}
+
+func (a AstPrinter) VisitInterpolationExpr(interpolation expr.Interpolation) (interface{}, error) {
+	return a.paranthesize("interpolate", interpolation.Parts...), nil
+}
+
+func (a AstPrinter) VisitListExpr(list expr.List) (interface{}, error) {
+	return a.paranthesize("list", list.Elements...), nil
+}
+
 func (a AstPrinter) VisitLiteralExpr(literal expr.Literal) (interface{}, error) {
 	if literal.Value == nil {
 		return "nil", nil
@@ -32,6 +60,21 @@ func (a AstPrinter) VisitLogicalExpr(logical expr.Logical) (interface{}, error)
 	return a.paranthesize(logical.Operator.Lexeme, logical.Left, logical.Right), nil
 }
 
+func (a AstPrinter) VisitMapExpr(m expr.Map) (interface{}, error) {
+	entries := make([]expr.Expr, 0, 2*len(m.Keys))
+	for i := range m.Keys {
+		entries = append(entries, m.Keys[i], m.Values[i])
+	}
+	return a.paranthesize("map", entries...), nil
+}
+
+func (a AstPrinter) VisitRangeExpr(r expr.Range) (interface{}, error) {
+	if r.Step == nil {
+		return a.paranthesize(r.Operator.Lexeme, r.Start, r.End), nil
+	}
+	return a.paranthesize(r.Operator.Lexeme, r.Start, r.End, r.Step), nil
+}
+
 func (a AstPrinter) VisitUnaryExpr(unary expr.Unary) (interface{}, error) {
 	return a.paranthesize(unary.Operator.Lexeme, unary.Right), nil
 }
@@ -1,30 +1,27 @@
 package environment
 
 import (
-	"errors"
 	"fmt"
 
+	"github.com/lmaraite/golox/loxerror"
 	"github.com/lmaraite/golox/token"
 )
 
 func newError(errorToken token.Token, message string) error {
-	var formattedMessage string
-	if errorToken.TokenType == token.EOF {
-		formattedMessage = fmt.Sprintf("[line %d] Runtime error at end: %s", errorToken.Line, message)
-	} else {
-		formattedMessage = fmt.Sprintf("[line %d] Runtime error at '%s': %s", errorToken.Line, errorToken.Lexeme, message)
-	}
-	return errors.New(formattedMessage)
+	return loxerror.NewRuntimeError(errorToken, message)
 }
 
 type Environment struct {
 	enclosing *Environment
 	values    map[string]interface{}
+	// constants maps the names of immutable bindings to their declaration
+	constants map[string]token.Token
 }
 
 func NewEmptyEnvironment() *Environment {
 	return &Environment{
-		values: make(map[string]interface{}),
+		values:    make(map[string]interface{}),
+		constants: make(map[string]token.Token),
 	}
 }
 
@@ -32,11 +29,15 @@ func NewEnvironment(enclosing *Environment) *Environment {
 	return &Environment{
 		enclosing: enclosing,
 		values:    make(map[string]interface{}),
+		constants: make(map[string]token.Token),
 	}
 }
 
 func (e *Environment) Assign(name token.Token, value interface{}) error {
 	if _, ok := e.values[name.Lexeme]; ok {
+		if declaration, ok := e.constants[name.Lexeme]; ok {
+			return newError(name, fmt.Sprintf("Cannot assign to constant '%s' declared at line %d.", name.Lexeme, declaration.Line))
+		}
 		e.values[name.Lexeme] = value
 		return nil
 	}
@@ -46,10 +47,19 @@ func (e *Environment) Assign(name token.Token, value interface{}) error {
 	return newError(name, "Undefined variable '"+name.Lexeme+"'.")
 }
 
+// Define binds a mutable variable, replacing any previous binding of the same name
 func (e *Environment) Define(name string, value interface{}) {
+	delete(e.constants, name)
 	e.values[name] = value
 }
 
+// DefineConst binds an immutable variable. The name token is kept to
+// point at the declaration when an assignment is rejected.
+func (e *Environment) DefineConst(name token.Token, value interface{}) {
+	e.constants[name.Lexeme] = name
+	e.values[name.Lexeme] = value
+}
+
 func (e *Environment) Get(name token.Token) (interface{}, error) {
 	if value, ok := e.values[name.Lexeme]; ok {
 		return value, nil
@@ -61,3 +71,10 @@ func (e *Environment) Get(name token.Token) (interface{}, error) {
 	}
 	return nil, newError(name, "Undefined variable '"+name.Lexeme+"'.")
 }
+
+// Contains checks whether name is bound in this environment itself,
+// ignoring enclosing environments
+func (e *Environment) Contains(name string) bool {
+	_, ok := e.values[name]
+	return ok
+}
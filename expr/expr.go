@@ -7,9 +7,18 @@ import (
 type Visitor interface {
 	VisitAssignExpr(assign Assign) (interface{}, error)
 	VisitBinaryExpr(binary Binary) (interface{}, error)
+	VisitCallExpr(call Call) (interface{}, error)
+	VisitConditionalExpr(conditional Conditional) (interface{}, error)
+	VisitGetExpr(get Get) (interface{}, error)
 	VisitGroupingExpr(grouping Grouping) (interface{}, error)
+	VisitIndexExpr(index Index) (interface{}, error)
+	VisitIndexSetExpr(indexSet IndexSet) (interface{}, error)
+	VisitInterpolationExpr(interpolation Interpolation) (interface{}, error)
+	VisitListExpr(list List) (interface{}, error)
 	VisitLiteralExpr(literal Literal) (interface{}, error)
 	VisitLogicalExpr(logical Logical) (interface{}, error)
+	VisitMapExpr(m Map) (interface{}, error)
+	VisitRangeExpr(r Range) (interface{}, error)
 	VisitUnaryExpr(unary Unary) (interface{}, error)
 	VisitVariableExpr(variable Variable) (interface{}, error)
 }
@@ -37,6 +46,35 @@ func (b Binary) Accept(visitor Visitor) (interface{}, error) {
 	return visitor.VisitBinaryExpr(b)
 }
 
+type Call struct {
+	Callee    Expr
+	Paren     token.Token
+	Arguments []Expr
+}
+
+func (c Call) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitCallExpr(c)
+}
+
+type Conditional struct {
+	Condition  Expr
+	ThenBranch Expr
+	ElseBranch Expr
+}
+
+func (c Conditional) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitConditionalExpr(c)
+}
+
+type Get struct {
+	Object Expr
+	Name   token.Token
+}
+
+func (g Get) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitGetExpr(g)
+}
+
 type Grouping struct {
 	Expression Expr
 }
@@ -45,6 +83,45 @@ func (g Grouping) Accept(visitor Visitor) (interface{}, error) {
 	return visitor.VisitGroupingExpr(g)
 }
 
+type Index struct {
+	Object  Expr
+	Bracket token.Token
+	Index   Expr
+}
+
+func (i Index) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitIndexExpr(i)
+}
+
+type IndexSet struct {
+	Object  Expr
+	Bracket token.Token
+	Index   Expr
+	Value   Expr
+}
+
+func (i IndexSet) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitIndexSetExpr(i)
+}
+
+// Interpolation is a string with embedded expressions. Parts holds the
+// string segments as literals interleaved with the embedded expressions.
+type Interpolation struct {
+	Parts []Expr
+}
+
+func (i Interpolation) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitInterpolationExpr(i)
+}
+
+type List struct {
+	Elements []Expr
+}
+
+func (l List) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitListExpr(l)
+}
+
 type Literal struct {
 	Value interface{}
 }
@@ -63,6 +140,31 @@ func (l Logical) Accept(visitor Visitor) (interface{}, error) {
 	return visitor.VisitLogicalExpr(l)
 }
 
+// Map is a map literal. The key at a position in Keys belongs to the value
+// at the same position in Values.
+type Map struct {
+	Brace  token.Token
+	Keys   []Expr
+	Values []Expr
+}
+
+func (m Map) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitMapExpr(m)
+}
+
+// Range is a range expression like 0..10 or 0..=10 step 2. Step is nil
+// if the range has no explicit step.
+type Range struct {
+	Start    Expr
+	Operator token.Token
+	End      Expr
+	Step     Expr
+}
+
+func (r Range) Accept(visitor Visitor) (interface{}, error) {
+	return visitor.VisitRangeExpr(r)
+}
+
 type Unary struct {
 	Operator token.Token
 	Right    Expr
@@ -0,0 +1,127 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// defineBuiltins populates the global environment with the core natives
+func (i *Interpreter) defineBuiltins() {
+	i.DefineNative("clock", 0, clock)
+	i.DefineNative("len", 1, length)
+	i.DefineNative("str", 1, str)
+	i.DefineNative("num", 1, num)
+	i.DefineNative("type", 1, typeName)
+	i.defineStdin()
+	i.defineMath()
+	i.defineStrings()
+	i.defineFileSystem()
+	i.defineJSON()
+	i.defineRandom()
+	i.defineRegex()
+}
+
+// clock returns the seconds elapsed since the Unix epoch
+func clock(arguments []Value) (Value, error) {
+	return float64(time.Now().UnixNano()) / float64(time.Second), nil
+}
+
+// length returns the number of characters of a string
+// or the number of elements of a list or map
+func length(arguments []Value) (Value, error) {
+	switch value := arguments[0].(type) {
+	case string:
+		return float64(utf8.RuneCountInString(value)), nil
+	case *List:
+		return float64(len(value.Elements)), nil
+	case *Map:
+		return float64(value.Len()), nil
+	}
+	return nil, errors.New("Argument must be a string, list or map.")
+}
+
+func str(arguments []Value) (Value, error) {
+	return stringify(arguments[0]), nil
+}
+
+// num converts a string to a number
+func num(arguments []Value) (Value, error) {
+	switch value := arguments[0].(type) {
+	case float64:
+		return value, nil
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot parse %s as a number.", strconv.Quote(value))
+		}
+		return number, nil
+	}
+	return nil, errors.New("Argument must be a string or number.")
+}
+
+// typeName returns the name of the type of a value
+func typeName(arguments []Value) (Value, error) {
+	switch value := arguments[0].(type) {
+	case nil:
+		return "nil", nil
+	case bool:
+		return "boolean", nil
+	case float64:
+		return "number", nil
+	case string:
+		return "string", nil
+	case *List:
+		return "list", nil
+	case *Map:
+		return "map", nil
+	case Range:
+		return "range", nil
+	case *Enum:
+		return "enum", nil
+	case *EnumMember:
+		// Members have their enum as type
+		return value.Enum.Name, nil
+	case *Namespace:
+		return "namespace", nil
+	case Callable:
+		return "function", nil
+	}
+	return "object", nil
+}
+
+// numberArgument returns the argument at index if it is a number
+func numberArgument(arguments []Value, index int) (float64, error) {
+	if number, ok := arguments[index].(float64); ok {
+		return number, nil
+	}
+	return 0, fmt.Errorf("Argument %d must be a number.", index+1)
+}
+
+// integerArgument returns the argument at index if it is an integral number
+func integerArgument(arguments []Value, index int) (int, error) {
+	if number, ok := arguments[index].(float64); ok && isInteger(number) {
+		return int(number), nil
+	}
+	return 0, fmt.Errorf("Argument %d must be an integer.", index+1)
+}
+
+// stringArgument returns the argument at index if it is a string
+func stringArgument(arguments []Value, index int) (string, error) {
+	if s, ok := arguments[index].(string); ok {
+		return s, nil
+	}
+	return "", fmt.Errorf("Argument %d must be a string.", index+1)
+}
+
+// stringList converts a slice of strings into a list
+func stringList(values []string) *List {
+	elements := make([]interface{}, len(values))
+	for index, s := range values {
+		elements[index] = s
+	}
+	return NewList(elements)
+}
@@ -0,0 +1,61 @@
+package interpreter
+
+import (
+	"github.com/lmaraite/golox/token"
+)
+
+// Enum is the runtime value of an enum declaration. It is the namespace
+// holding the enum's members.
+type Enum struct {
+	Name    string
+	Members []*EnumMember
+}
+
+// EnumMember is a single value of an enum
+type EnumMember struct {
+	Enum    *Enum
+	Name    string
+	Ordinal int
+}
+
+func NewEnum(name string, members []string) *Enum {
+	enum := &Enum{Name: name}
+	for ordinal, member := range members {
+		enum.Members = append(enum.Members, &EnumMember{
+			Enum:    enum,
+			Name:    member,
+			Ordinal: ordinal,
+		})
+	}
+	return enum
+}
+
+// Get returns the member of the enum with the given name
+func (e *Enum) Get(name token.Token) (interface{}, error) {
+	for _, member := range e.Members {
+		if member.Name == name.Lexeme {
+			return member, nil
+		}
+	}
+	return nil, newError(name, "Undefined member '"+name.Lexeme+"' of enum '"+e.Name+"'.")
+}
+
+// Iterator returns an iterator over the members of the enum in declaration order
+func (e *Enum) Iterator() Iterator {
+	members := make([]interface{}, len(e.Members))
+	for i, member := range e.Members {
+		members[i] = member
+	}
+	return NewList(members).Iterator()
+}
+
+// Get returns the value of the accessors name and ordinal
+func (m *EnumMember) Get(name token.Token) (interface{}, error) {
+	switch name.Lexeme {
+	case "name":
+		return m.Name, nil
+	case "ordinal":
+		return float64(m.Ordinal), nil
+	}
+	return nil, newError(name, "Enum members only have the properties 'name' and 'ordinal'.")
+}
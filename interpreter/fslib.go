@@ -0,0 +1,198 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AllowFileSystemRoot grants scripts access to the directory dir and
+// everything below it through the fs namespace. Without any allowed
+// root every fs native fails.
+func (i *Interpreter) AllowFileSystemRoot(dir string) error {
+	root, err := resolvePath(dir)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	i.fileSystemRoots = append(i.fileSystemRoots, root)
+	return nil
+}
+
+// defineFileSystem defines the fs namespace
+func (i *Interpreter) defineFileSystem() {
+	namespace := i.DefineNamespace("fs")
+	namespace.DefineNative("readFile", 1, i.readFile)
+	namespace.DefineNative("writeFile", 2, i.writeFile(os.O_TRUNC))
+	namespace.DefineNative("appendFile", 2, i.writeFile(os.O_APPEND))
+	namespace.DefineNative("listDir", 1, i.listDir)
+	namespace.DefineNative("exists", 1, i.exists)
+	namespace.DefineNative("mkdir", 1, i.mkdir)
+	namespace.DefineNative("remove", 1, i.remove)
+}
+
+// allowedPath returns the resolved path of the argument at index
+// if it lies within one of the allowed roots
+func (i *Interpreter) allowedPath(arguments []Value, index int) (string, error) {
+	path, err := stringArgument(arguments, index)
+	if err != nil {
+		return "", err
+	}
+	if len(i.fileSystemRoots) == 0 {
+		return "", errors.New("File system access is disabled.")
+	}
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return "", err
+	}
+	for _, root := range i.fileSystemRoots {
+		relative, err := filepath.Rel(root, resolved)
+		if err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			return resolved, nil
+		}
+	}
+	return "", fmt.Errorf("Access to '%s' is not allowed.", path)
+}
+
+// maxLinks limits the number of dangling symbolic links followed
+// while resolving a path, to detect cycles of links
+const maxLinks = 40
+
+// resolvePath makes path absolute and resolves symbolic links, so that
+// links cannot lead outside of an allowed root. Paths that do not exist
+// yet are resolved up to their longest existing parent.
+func resolvePath(path string) (string, error) {
+	return resolvePathFollowing(path, 0)
+}
+
+// resolvePathFollowing resolves path, having already followed links dangling
+// links. A dangling link is resolved to its target rather than treated like a
+// missing file, since creating a file through the link creates the target.
+func resolvePathFollowing(path string, links int) (string, error) {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	existing, rest := absolutePath, ""
+	for {
+		resolved, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		if info, err := os.Lstat(existing); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			if links == maxLinks {
+				return "", fmt.Errorf("Too many symbolic links in '%s'.", path)
+			}
+			target, err := os.Readlink(existing)
+			if err != nil {
+				return "", err
+			}
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(filepath.Dir(existing), target)
+			}
+			return resolvePathFollowing(filepath.Join(target, rest), links+1)
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return absolutePath, nil
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+}
+
+func (i *Interpreter) readFile(arguments []Value) (Value, error) {
+	path, err := i.allowedPath(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// writeFile returns a native writing a string to a file, creating it if
+// necessary. mode decides whether an existing file is truncated or appended to.
+func (i *Interpreter) writeFile(mode int) NativeFunction {
+	return func(arguments []Value) (Value, error) {
+		path, err := i.allowedPath(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		content, err := stringArgument(arguments, 1)
+		if err != nil {
+			return nil, err
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|mode, 0644)
+		if err != nil {
+			return nil, err
+		}
+		_, err = file.WriteString(content)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		return nil, err
+	}
+}
+
+// listDir returns the sorted names of the entries of a directory
+func (i *Interpreter) listDir(arguments []Value) (Value, error) {
+	path, err := i.allowedPath(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(entries))
+	for index, entry := range entries {
+		names[index] = entry.Name()
+	}
+	sort.Strings(names)
+	return stringList(names), nil
+}
+
+func (i *Interpreter) exists(arguments []Value) (Value, error) {
+	path, err := i.allowedPath(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// mkdir creates a directory along with any missing parents
+func (i *Interpreter) mkdir(arguments []Value) (Value, error) {
+	path, err := i.allowedPath(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return nil, os.MkdirAll(path, 0755)
+}
+
+// remove removes a file or an empty directory
+func (i *Interpreter) remove(arguments []Value) (Value, error) {
+	path, err := i.allowedPath(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return nil, os.Remove(path)
+}
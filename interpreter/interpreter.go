@@ -1,34 +1,92 @@
 package interpreter
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
-	"reflect"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/lmaraite/golox/environment"
 	"github.com/lmaraite/golox/expr"
+	"github.com/lmaraite/golox/loxerror"
 	"github.com/lmaraite/golox/stmt"
 	"github.com/lmaraite/golox/token"
 )
 
 func newError(errorToken token.Token, message string) error {
-	var formattedMessage string
-	if errorToken.TokenType == token.EOF {
-		formattedMessage = fmt.Sprintf("[line %d] Runtime error at end: %s", errorToken.Line, message)
-	} else {
-		formattedMessage = fmt.Sprintf("[line %d] Runtime error at '%s': %s", errorToken.Line, errorToken.Lexeme, message)
-	}
-	return errors.New(formattedMessage)
+	return loxerror.NewRuntimeError(errorToken, message)
+}
+
+// thrown is the error a throw statement unwinds the stack with
+type thrown struct {
+	keyword token.Token
+	value   interface{}
+}
+
+func (t *thrown) Error() string {
+	return fmt.Sprintf("[line %d] Uncaught exception: %s", t.keyword.Line, stringify(t.value))
 }
 
 type Interpreter struct {
-	env *environment.Environment
+	// globals is shared by the script and all modules it imports,
+	// each of which has its own top-level environment enclosed by it
+	globals           *environment.Environment
+	env               *environment.Environment
+	assertionsEnabled bool
+	// file is the path of the script or module currently executed
+	file string
+	// modules caches the namespaces of all modules loaded so far by their path
+	modules map[string]*Namespace
+	// importing holds the paths of the modules currently being loaded,
+	// the innermost last
+	importing []string
+	// fileSystemRoots holds the resolved directories the fs natives may access
+	fileSystemRoots []string
+	stdin           *bufio.Reader
+	// random is the generator of the random namespace
+	random *rand.Rand
+	// patterns caches the regular expressions compiled by the regex namespace
+	patterns map[string]*regexp.Regexp
 }
 
 func NewInterpreter() *Interpreter {
-	return &Interpreter{
-		env: environment.NewEmptyEnvironment(),
+	globals := environment.NewEmptyEnvironment()
+	interpreter := &Interpreter{
+		globals:           globals,
+		env:               environment.NewEnvironment(globals),
+		assertionsEnabled: true,
+		modules:           make(map[string]*Namespace),
+		stdin:             bufio.NewReader(os.Stdin),
+		random:            rand.New(rand.NewSource(time.Now().UnixNano())),
+		patterns:          make(map[string]*regexp.Regexp),
 	}
+	interpreter.defineBuiltins()
+	return interpreter
+}
+
+// SetScriptPath sets the path of the executed script,
+// relative to which its imports are resolved
+func (i *Interpreter) SetScriptPath(path string) error {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	i.file = absolutePath
+	i.importing = []string{absolutePath}
+	return nil
+}
+
+// SetAssertionsEnabled controls whether assert statements are executed.
+// Disabled assertions do not evaluate their condition at all.
+func (i *Interpreter) SetAssertionsEnabled(enabled bool) {
+	i.assertionsEnabled = enabled
 }
 
 func (i *Interpreter) Interpret(statements []stmt.Stmt) error {
@@ -59,15 +117,138 @@ func (i *Interpreter) executeBlock(statements []stmt.Stmt, env *environment.Envi
 	return nil
 }
 
+func (i *Interpreter) VisitAssertStmt(statement stmt.Assert) error {
+	if !i.assertionsEnabled {
+		return nil
+	}
+	var condition interface{}
+	var operands string
+	if binary, ok := statement.Condition.(expr.Binary); ok && isComparison(binary.Operator) {
+		// Evaluate the operands separately to report their values
+		left, err := i.Evaluate(binary.Left)
+		if err != nil {
+			return err
+		}
+		right, err := i.Evaluate(binary.Right)
+		if err != nil {
+			return err
+		}
+		condition, err = applyBinary(binary.Operator, left, right)
+		if err != nil {
+			return err
+		}
+		operands = stringifyElement(left) + " " + binary.Operator.Lexeme + " " + stringifyElement(right)
+	} else {
+		var err error
+		condition, err = i.Evaluate(statement.Condition)
+		if err != nil {
+			return err
+		}
+	}
+	if isTruthy(condition) {
+		return nil
+	}
+
+	var message string
+	if statement.Message != nil {
+		value, err := i.Evaluate(statement.Message)
+		if err != nil {
+			return err
+		}
+		message = stringify(value)
+	}
+	return &loxerror.AssertionError{
+		Token:    statement.Keyword,
+		Source:   statement.Source,
+		Operands: operands,
+		Message:  message,
+	}
+}
+
+func (i *Interpreter) VisitAssignStmt(statement stmt.Assign) error {
+	values, err := i.evaluateValues(statement.Equals, len(statement.Targets), statement.Values)
+	if err != nil {
+		return err
+	}
+	for index, target := range statement.Targets {
+		switch target := target.(type) {
+		case expr.Variable:
+			err = i.env.Assign(target.Name, values[index])
+		case expr.Index:
+			err = i.assignIndex(target, values[index])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignIndex evaluates an index expression used as an assignment target and stores value there
+func (i *Interpreter) assignIndex(target expr.Index, value interface{}) error {
+	object, err := i.Evaluate(target.Object)
+	if err != nil {
+		return err
+	}
+	key, err := i.Evaluate(target.Index)
+	if err != nil {
+		return err
+	}
+	return setIndex(target.Bracket, object, key, value)
+}
+
 func (i *Interpreter) VisitBlockStmt(statement stmt.Block) error {
 	return i.executeBlock(statement.Statements, environment.NewEnvironment(i.env))
 }
 
+func (i *Interpreter) VisitConstStmt(statement stmt.Const) error {
+	values, err := i.evaluateValues(statement.Names[0], len(statement.Names), statement.Initializers)
+	if err != nil {
+		return err
+	}
+	for index, name := range statement.Names {
+		i.env.DefineConst(name, values[index])
+	}
+	return nil
+}
+
+func (i *Interpreter) VisitEnumStmt(statement stmt.Enum) error {
+	names := make([]string, len(statement.Members))
+	for index, member := range statement.Members {
+		names[index] = member.Lexeme
+	}
+	i.env.DefineConst(statement.Name, NewEnum(statement.Name.Lexeme, names))
+	return nil
+}
+
 func (i *Interpreter) VisitExprStmt(statement stmt.Expr) error {
 	_, err := i.Evaluate(statement.Expression)
 	return err
 }
 
+func (i *Interpreter) VisitForInStmt(statement stmt.ForIn) error {
+	iterable, err := i.Evaluate(statement.Iterable)
+	if err != nil {
+		return err
+	}
+	iterator, err := iterate(statement.In, iterable)
+	if err != nil {
+		return err
+	}
+	for {
+		value, ok := iterator.Next()
+		if !ok {
+			return nil
+		}
+		env := environment.NewEnvironment(i.env)
+		env.Define(statement.Name.Lexeme, value)
+		err = i.executeBlock([]stmt.Stmt{statement.Body}, env)
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (i *Interpreter) VisitIfStmt(statement stmt.If) error {
 	condition, err := i.Evaluate(statement.Condition)
 	if err != nil {
@@ -75,9 +256,76 @@ func (i *Interpreter) VisitIfStmt(statement stmt.If) error {
 	}
 	if isTruthy(condition) {
 		return i.execute(statement.ThenBranch)
-	} else {
+	} else if statement.ElseBranch != nil {
 		return i.execute(statement.ElseBranch)
 	}
+	return nil
+}
+
+func (i *Interpreter) VisitImportStmt(statement stmt.Import) error {
+	namespace, err := i.importModule(statement.Keyword, statement.Path.Literal.(string))
+	if err != nil {
+		return err
+	}
+	i.env.DefineConst(statement.Name, namespace)
+	return nil
+}
+
+func (i *Interpreter) VisitMatchStmt(statement stmt.Match) error {
+	subject, err := i.Evaluate(statement.Subject)
+	if err != nil {
+		return err
+	}
+	for _, matchCase := range statement.Cases {
+		env := environment.NewEnvironment(i.env)
+		matched, err := i.matchPatterns(matchCase.Patterns, subject, env)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+		if matchCase.Guard != nil {
+			previousEnv := i.env
+			i.env = env
+			guard, err := i.Evaluate(matchCase.Guard)
+			i.env = previousEnv
+			if err != nil {
+				return err
+			}
+			if !isTruthy(guard) {
+				continue
+			}
+		}
+		return i.executeBlock([]stmt.Stmt{matchCase.Body}, env)
+	}
+	if statement.Else != nil {
+		return i.execute(statement.Else)
+	}
+	return nil
+}
+
+// matchPatterns checks whether any of the patterns matches subject. A matching
+// binding pattern defines its variable in env.
+func (i *Interpreter) matchPatterns(patterns []stmt.Pattern, subject interface{}, env *environment.Environment) (bool, error) {
+	for _, pattern := range patterns {
+		if pattern.Binding != nil {
+			env.Define(pattern.Binding.Lexeme, subject)
+			return true, nil
+		}
+		value, err := i.Evaluate(pattern.Value)
+		if err != nil {
+			return false, err
+		}
+		if r, ok := value.(Range); ok {
+			if number, ok := subject.(float64); ok && r.Contains(number) {
+				return true, nil
+			}
+		} else if isEqual(value, subject) {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func (i *Interpreter) VisitPrintStmt(statement stmt.Print) error {
@@ -85,23 +333,104 @@ func (i *Interpreter) VisitPrintStmt(statement stmt.Print) error {
 	if err != nil {
 		return err
 	}
-	fmt.Println(value)
+	fmt.Println(stringify(value))
 	return nil
 }
 
+func (i *Interpreter) VisitThrowStmt(statement stmt.Throw) error {
+	value, err := i.Evaluate(statement.Value)
+	if err != nil {
+		return err
+	}
+	return &thrown{keyword: statement.Keyword, value: value}
+}
+
+func (i *Interpreter) VisitTryStmt(statement stmt.Try) error {
+	err := i.executeBlock(statement.Body.Statements, environment.NewEnvironment(i.env))
+	if err != nil && statement.Catch != nil {
+		if exception, ok := caught(err); ok {
+			env := environment.NewEnvironment(i.env)
+			env.Define(statement.CatchName.Lexeme, exception)
+			err = i.executeBlock(statement.Catch.Statements, env)
+		}
+	}
+	if statement.Finally != nil {
+		finallyErr := i.executeBlock(statement.Finally.Statements, environment.NewEnvironment(i.env))
+		if finallyErr != nil {
+			return finallyErr
+		}
+	}
+	return err
+}
+
+// caught converts an error into the value a catch clause receives. Thrown values
+// are passed on unchanged, runtime errors become a map with their message and line.
+func caught(err error) (interface{}, bool) {
+	var exception *thrown
+	if errors.As(err, &exception) {
+		return exception.value, true
+	}
+	var runtimeError *loxerror.RuntimeError
+	if errors.As(err, &runtimeError) {
+		return runtimeErrorValue(runtimeError.Message, runtimeError.Token.Line), true
+	}
+	var assertionError *loxerror.AssertionError
+	if errors.As(err, &assertionError) {
+		return runtimeErrorValue(assertionError.Description(), assertionError.Token.Line), true
+	}
+	return nil, false
+}
+
+func runtimeErrorValue(message string, line int) *Map {
+	value := NewMap()
+	value.values["message"] = message
+	value.values["line"] = float64(line)
+	value.keys = append(value.keys, "message", "line")
+	return value
+}
+
 func (i *Interpreter) VisitVarStmt(statement stmt.Var) error {
-	if statement.Initializer != nil {
-		value, err := i.Evaluate(statement.Initializer)
-		if err != nil {
-			return err
+	if statement.Initializers == nil {
+		for _, name := range statement.Names {
+			i.env.Define(name.Lexeme, nil)
 		}
-		i.env.Define(statement.Name.Lexeme, value)
-	} else {
-		i.env.Define(statement.Name.Lexeme, nil)
+		return nil
+	}
+	values, err := i.evaluateValues(statement.Names[0], len(statement.Names), statement.Initializers)
+	if err != nil {
+		return err
+	}
+	for index, name := range statement.Names {
+		i.env.Define(name.Lexeme, values[index])
 	}
 	return nil
 }
 
+// evaluateValues evaluates the right-hand side of a declaration or assignment
+// with count targets before any of them is bound. A single list is unpacked if
+// there are several targets.
+func (i *Interpreter) evaluateValues(errorToken token.Token, count int, expressions []expr.Expr) ([]interface{}, error) {
+	values := make([]interface{}, len(expressions))
+	for index, expression := range expressions {
+		value, err := i.Evaluate(expression)
+		if err != nil {
+			return nil, err
+		}
+		values[index] = value
+	}
+	if len(values) == count {
+		return values, nil
+	}
+	list, ok := values[0].(*List)
+	if !ok {
+		return nil, newError(errorToken, fmt.Sprintf("Expected %d values but got a single value that is not a list.", count))
+	}
+	if len(list.Elements) != count {
+		return nil, newError(errorToken, fmt.Sprintf("Cannot unpack %d values into %d targets.", len(list.Elements), count))
+	}
+	return list.Elements, nil
+}
+
 func (i *Interpreter) VisitWhileStmt(statement stmt.While) error {
 	condition, err := i.Evaluate(statement.Condition)
 	if err != nil {
@@ -137,49 +466,123 @@ func (i *Interpreter) VisitBinaryExpr(binary expr.Binary) (interface{}, error) {
 		return nil, err
 	}
 
-	switch binary.Operator.TokenType {
+	return applyBinary(binary.Operator, left, right)
+}
+
+// applyBinary applies a binary operator to its already evaluated operands
+func applyBinary(operator token.Token, left, right interface{}) (interface{}, error) {
+	switch operator.TokenType {
 	case token.GREATER:
-		err := checkNumberOperands(binary.Operator, left, right)
+		err := checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 		return left.(float64) > right.(float64), nil
 	case token.GREATER_EQUAL:
-		err := checkNumberOperands(binary.Operator, left, right)
+		err := checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 		return left.(float64) >= right.(float64), nil
 	case token.LESS:
-		err := checkNumberOperands(binary.Operator, left, right)
+		err := checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 		return left.(float64) < right.(float64), nil
 	case token.LESS_EQUAL:
-		err := checkNumberOperands(binary.Operator, left, right)
+		err := checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 		return left.(float64) <= right.(float64), nil
 	case token.MINUS:
-		err := checkNumberOperands(binary.Operator, left, right)
+		err := checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 		return left.(float64) - right.(float64), nil
 	case token.PLUS:
-		if reflect.TypeOf(left).Name() == "float64" && reflect.TypeOf(right).Name() == "float64" {
-			return left.(float64) + right.(float64), nil
+		if a, ok := left.(float64); ok {
+			if b, ok := right.(float64); ok {
+				return a + b, nil
+			}
 		}
-		if reflect.TypeOf(left).Name() == "string" && reflect.TypeOf(right).Name() == "string" {
-			return left.(string) + right.(string), nil
+		if a, ok := left.(string); ok {
+			if b, ok := right.(string); ok {
+				return a + b, nil
+			}
 		}
-		return nil, newError(binary.Operator, "Operands must be two numbers or two strings.")
+		return nil, newError(operator, "Operands must be two numbers or two strings.")
 	case token.SLASH:
+		err := checkNumberOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
 		return left.(float64) / right.(float64), nil
 	case token.STAR:
+		err := checkNumberOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
 		return left.(float64) * right.(float64), nil
+	case token.PERCENT:
+		err := checkNumberOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+		if right.(float64) == 0 {
+			return nil, newError(operator, "Modulo by zero.")
+		}
+		// Like the Java implementation of Lox, the result takes the sign of the dividend
+		return math.Mod(left.(float64), right.(float64)), nil
+	case token.STAR_STAR:
+		err := checkNumberOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+		if left.(float64) == 0 && right.(float64) < 0 {
+			return nil, newError(operator, "Zero cannot be raised to a negative power.")
+		}
+		if left.(float64) < 0 && right.(float64) != math.Trunc(right.(float64)) {
+			return nil, newError(operator, "Negative base requires an integer exponent.")
+		}
+		return math.Pow(left.(float64), right.(float64)), nil
+	case token.AMPERSAND:
+		a, b, err := checkIntegerOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+		return float64(a & b), nil
+	case token.PIPE:
+		a, b, err := checkIntegerOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+		return float64(a | b), nil
+	case token.CARET:
+		a, b, err := checkIntegerOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+		return float64(a ^ b), nil
+	case token.LESS_LESS:
+		a, b, err := checkShiftOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+		if a > maxSafeInteger>>b || a < -maxSafeInteger>>b {
+			return nil, newError(operator, "Result of shift is not a safe integer.")
+		}
+		return float64(a << b), nil
+	case token.GREATER_GREATER:
+		a, b, err := checkShiftOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+		return float64(a >> b), nil
+	case token.IN:
+		return contains(operator, right, left)
 	case token.BANG_EQUAL:
 		return !isEqual(left, right), nil
 	case token.EQUAL_EQUAL:
@@ -188,10 +591,140 @@ func (i *Interpreter) VisitBinaryExpr(binary expr.Binary) (interface{}, error) {
 	return nil, nil
 }
 
+func (i *Interpreter) VisitCallExpr(call expr.Call) (interface{}, error) {
+	callee, err := i.Evaluate(call.Callee)
+	if err != nil {
+		return nil, err
+	}
+	arguments := make([]Value, len(call.Arguments))
+	for index, argument := range call.Arguments {
+		arguments[index], err = i.Evaluate(argument)
+		if err != nil {
+			return nil, err
+		}
+	}
+	function, ok := callee.(Callable)
+	if !ok {
+		return nil, newError(call.Paren, "Can only call functions.")
+	}
+	if arity := function.Arity(); arity >= 0 && arity != len(arguments) {
+		return nil, newError(call.Paren, fmt.Sprintf("Expected %d arguments but got %d.", arity, len(arguments)))
+	}
+	result, err := function.Call(arguments)
+	if err != nil {
+		if _, ok := caught(err); ok {
+			return nil, err
+		}
+		// Errors of natives are reported at the call site
+		return nil, newError(call.Paren, err.Error())
+	}
+	return result, nil
+}
+
+func (i *Interpreter) VisitConditionalExpr(conditional expr.Conditional) (interface{}, error) {
+	condition, err := i.Evaluate(conditional.Condition)
+	if err != nil {
+		return nil, err
+	}
+	if isTruthy(condition) {
+		return i.Evaluate(conditional.ThenBranch)
+	}
+	return i.Evaluate(conditional.ElseBranch)
+}
+
+func (i *Interpreter) VisitGetExpr(get expr.Get) (interface{}, error) {
+	object, err := i.Evaluate(get.Object)
+	if err != nil {
+		return nil, err
+	}
+	switch object := object.(type) {
+	case *Enum:
+		return object.Get(get.Name)
+	case *EnumMember:
+		return object.Get(get.Name)
+	case *Namespace:
+		return object.Get(get.Name)
+	}
+	return nil, newError(get.Name, "Only namespaces, enums and enum members have properties.")
+}
+
 func (i *Interpreter) VisitGroupingExpr(grouping expr.Grouping) (interface{}, error) {
 	return i.Evaluate(grouping.Expression)
 }
 
+func (i *Interpreter) VisitIndexExpr(index expr.Index) (interface{}, error) {
+	object, err := i.Evaluate(index.Object)
+	if err != nil {
+		return nil, err
+	}
+	key, err := i.Evaluate(index.Index)
+	if err != nil {
+		return nil, err
+	}
+	switch object := object.(type) {
+	case *List:
+		return object.Get(index.Bracket, key)
+	case *Map:
+		return object.Get(index.Bracket, key)
+	}
+	return nil, newError(index.Bracket, "Only lists and maps can be indexed.")
+}
+
+func (i *Interpreter) VisitIndexSetExpr(indexSet expr.IndexSet) (interface{}, error) {
+	object, err := i.Evaluate(indexSet.Object)
+	if err != nil {
+		return nil, err
+	}
+	key, err := i.Evaluate(indexSet.Index)
+	if err != nil {
+		return nil, err
+	}
+	value, err := i.Evaluate(indexSet.Value)
+	if err != nil {
+		return nil, err
+	}
+	err = setIndex(indexSet.Bracket, object, key, value)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// setIndex stores value at the position of a list or the key of a map
+func setIndex(bracket token.Token, object, key, value interface{}) error {
+	switch object := object.(type) {
+	case *List:
+		return object.Set(bracket, key, value)
+	case *Map:
+		return object.Set(bracket, key, value)
+	}
+	return newError(bracket, "Only lists and maps can be indexed.")
+}
+
+func (i *Interpreter) VisitInterpolationExpr(interpolation expr.Interpolation) (interface{}, error) {
+	var builder strings.Builder
+	for _, part := range interpolation.Parts {
+		value, err := i.Evaluate(part)
+		if err != nil {
+			return nil, err
+		}
+		builder.WriteString(stringify(value))
+	}
+	return builder.String(), nil
+}
+
+func (i *Interpreter) VisitListExpr(list expr.List) (interface{}, error) {
+	elements := make([]interface{}, len(list.Elements))
+	for index, element := range list.Elements {
+		value, err := i.Evaluate(element)
+		if err != nil {
+			return nil, err
+		}
+		elements[index] = value
+	}
+	return NewList(elements), nil
+}
+
 func (i *Interpreter) VisitLiteralExpr(literal expr.Literal) (interface{}, error) {
 	return literal.Value, nil
 }
@@ -212,6 +745,61 @@ func (i *Interpreter) VisitLogicalExpr(logical expr.Logical) (interface{}, error
 	}
 }
 
+func (i *Interpreter) VisitMapExpr(m expr.Map) (interface{}, error) {
+	result := NewMap()
+	for index := range m.Keys {
+		key, err := i.Evaluate(m.Keys[index])
+		if err != nil {
+			return nil, err
+		}
+		value, err := i.Evaluate(m.Values[index])
+		if err != nil {
+			return nil, err
+		}
+		err = result.Set(m.Brace, key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (i *Interpreter) VisitRangeExpr(r expr.Range) (interface{}, error) {
+	start, err := i.Evaluate(r.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := i.Evaluate(r.End)
+	if err != nil {
+		return nil, err
+	}
+	_, startIsNumber := start.(float64)
+	_, endIsNumber := end.(float64)
+	if !startIsNumber || !endIsNumber {
+		return nil, newError(r.Operator, "Range bounds must be numbers.")
+	}
+	var step interface{} = 1.0
+	if r.Step != nil {
+		step, err = i.Evaluate(r.Step)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := step.(float64); !ok {
+			return nil, newError(r.Operator, "Range step must be a number.")
+		}
+		if step.(float64) == 0 {
+			return nil, newError(r.Operator, "Range step must not be zero.")
+		}
+	}
+	return Range{
+		Start:     start.(float64),
+		End:       end.(float64),
+		Step:      step.(float64),
+		Inclusive: r.Operator.TokenType == token.DOT_DOT_EQUAL,
+		Stepped:   r.Step != nil,
+	}, nil
+}
+
 func (i *Interpreter) VisitUnaryExpr(unary expr.Unary) (interface{}, error) {
 	right, err := i.Evaluate(unary.Right)
 	if err != nil {
@@ -227,6 +815,12 @@ func (i *Interpreter) VisitUnaryExpr(unary expr.Unary) (interface{}, error) {
 			return nil, err
 		}
 		return -right.(float64), nil
+	case token.TILDE:
+		operand, err := checkIntegerOperand(unary.Operator, right)
+		if err != nil {
+			return nil, err
+		}
+		return float64(^operand), nil
 	}
 	return nil, nil // unreachable
 }
@@ -240,22 +834,111 @@ func (i *Interpreter) VisitAssignExpr(assign expr.Assign) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	i.env.Assign(assign.Name, value)
+	err = i.env.Assign(assign.Name, value)
+	if err != nil {
+		return nil, err
+	}
 	return value, nil
 }
 
 func checkNumberOperand(operator token.Token, operand interface{}) error {
-	if reflect.TypeOf(operand).String() == "float64" {
+	if _, ok := operand.(float64); ok {
 		return nil
 	}
-	return newError(operator, "Operands must be a numbers.")
+	return newError(operator, "Operand must be a number.")
 }
 
 func checkNumberOperands(operator token.Token, left, right interface{}) error {
-	if reflect.TypeOf(left).String() == "float64" && reflect.TypeOf(right).String() == "float64" {
+	_, leftIsNumber := left.(float64)
+	_, rightIsNumber := right.(float64)
+	if leftIsNumber && rightIsNumber {
 		return nil
 	}
-	return newError(operator, "Operands must be a numbers.")
+	return newError(operator, "Operands must be numbers.")
+}
+
+// maxSafeInteger is the largest integer up to which every integer is exactly
+// representable as a float64
+const maxSafeInteger = 1 << 53
+
+// checkIntegerOperand returns the operand as an integer if it is a number
+// without a fractional part that can be represented exactly
+func checkIntegerOperand(operator token.Token, operand interface{}) (int64, error) {
+	if number, ok := operand.(float64); ok && isInteger(number) {
+		return int64(number), nil
+	}
+	return 0, newError(operator, "Operand must be an integer.")
+}
+
+func checkIntegerOperands(operator token.Token, left, right interface{}) (int64, int64, error) {
+	a, okLeft := left.(float64)
+	b, okRight := right.(float64)
+	if okLeft && okRight && isInteger(a) && isInteger(b) {
+		return int64(a), int64(b), nil
+	}
+	return 0, 0, newError(operator, "Operands must be integers.")
+}
+
+// maxShiftCount is the largest shift count that can
+// still produce a safe integer other than 0
+const maxShiftCount = 53
+
+// checkShiftOperands checks that both operands of a shift are integers
+// and that the shift count is between 0 and maxShiftCount
+func checkShiftOperands(operator token.Token, left, right interface{}) (int64, int64, error) {
+	a, b, err := checkIntegerOperands(operator, left, right)
+	if err != nil {
+		return 0, 0, err
+	}
+	if b < 0 {
+		return 0, 0, newError(operator, "Shift count must not be negative.")
+	}
+	if b > maxShiftCount {
+		return 0, 0, newError(operator, fmt.Sprintf("Shift count must not exceed %d.", maxShiftCount))
+	}
+	return a, b, nil
+}
+
+func isInteger(number float64) bool {
+	return number == math.Trunc(number) && math.Abs(number) <= maxSafeInteger
+}
+
+// isComparison checks whether operator compares its operands
+func isComparison(operator token.Token) bool {
+	switch operator.TokenType {
+	case token.EQUAL_EQUAL, token.BANG_EQUAL, token.GREATER, token.GREATER_EQUAL,
+		token.LESS, token.LESS_EQUAL, token.IN:
+		return true
+	}
+	return false
+}
+
+// contains implements the membership operator "in"
+func contains(operator token.Token, container, element interface{}) (bool, error) {
+	switch container := container.(type) {
+	case Range:
+		number, ok := element.(float64)
+		return ok && container.Contains(number), nil
+	case *List:
+		for _, v := range container.Elements {
+			if isEqual(v, element) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case *Map:
+		if !isHashable(element) {
+			return false, nil
+		}
+		_, ok := container.values[element]
+		return ok, nil
+	case string:
+		if substring, ok := element.(string); ok {
+			return strings.Contains(container, substring), nil
+		}
+		return false, newError(operator, "Left operand must be a string when searching a string.")
+	}
+	return false, newError(operator, "Right operand must be a range, list, map or string.")
 }
 
 func isEqual(a, b interface{}) bool {
@@ -265,6 +948,11 @@ func isEqual(a, b interface{}) bool {
 	if a == nil {
 		return false
 	}
+	if a, ok := a.(*EnumMember); ok {
+		// Members of different enums are never equal, even if they share name and ordinal
+		b, ok := b.(*EnumMember)
+		return ok && a.Enum == b.Enum && a.Ordinal == b.Ordinal
+	}
 	return a == b
 }
 
@@ -277,3 +965,72 @@ func isTruthy(i interface{}) bool {
 	}
 	return false
 }
+
+// stringify returns the representation of a value used by print and string interpolation
+func stringify(value interface{}) string {
+	return stringifyValue(value, make(map[interface{}]bool))
+}
+
+// stringifyValue returns the representation of a value. visiting holds the
+// lists and maps being printed, so that one containing itself is printed
+// as [...] or #{...} instead of recursing endlessly.
+func stringifyValue(value interface{}, visiting map[interface{}]bool) string {
+	switch value := value.(type) {
+	case nil:
+		return "nil"
+	case *List:
+		if visiting[value] {
+			return "[...]"
+		}
+		visiting[value] = true
+		defer delete(visiting, value)
+		elements := make([]string, len(value.Elements))
+		for i, element := range value.Elements {
+			elements[i] = stringifyNested(element, visiting)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case *Map:
+		if visiting[value] {
+			return "#{...}"
+		}
+		visiting[value] = true
+		defer delete(visiting, value)
+		entries := make([]string, len(value.keys))
+		for i, key := range value.keys {
+			entries[i] = stringifyNested(key, visiting) + ": " + stringifyNested(value.values[key], visiting)
+		}
+		return "#{" + strings.Join(entries, ", ") + "}"
+	case Range:
+		operator := ".."
+		if value.Inclusive {
+			operator = "..="
+		}
+		text := fmt.Sprint(value.Start) + operator + fmt.Sprint(value.End)
+		if value.Step != 1 {
+			text += " step " + fmt.Sprint(value.Step)
+		}
+		return text
+	case *Enum:
+		return "<enum " + value.Name + ">"
+	case *EnumMember:
+		return value.Enum.Name + "." + value.Name
+	case *Namespace:
+		return "<namespace " + value.Name + ">"
+	case *Native:
+		return "<native fn " + value.name + ">"
+	}
+	return fmt.Sprint(value)
+}
+
+// stringifyElement returns the representation of a value nested in a list or map.
+// Strings are quoted so that their boundaries stay visible.
+func stringifyElement(value interface{}) string {
+	return stringifyNested(value, make(map[interface{}]bool))
+}
+
+func stringifyNested(value interface{}, visiting map[interface{}]bool) string {
+	if s, ok := value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return stringifyValue(value, visiting)
+}
@@ -0,0 +1,63 @@
+package interpreter
+
+import (
+	"unicode/utf8"
+
+	"github.com/lmaraite/golox/token"
+)
+
+// Iterator yields the elements of an iterable value one after another
+type Iterator interface {
+	// Next returns the next element and true,
+	// or false once all elements have been yielded
+	Next() (interface{}, bool)
+}
+
+// Iterable is implemented by values a for-in loop can iterate over.
+// Host-provided Go values implementing it can be iterated by scripts as well.
+type Iterable interface {
+	Iterator() Iterator
+}
+
+// iterate returns an iterator over the elements of value
+func iterate(errorToken token.Token, value interface{}) (Iterator, error) {
+	switch value := value.(type) {
+	case string:
+		return &stringIterator{text: value}, nil
+	case Iterable:
+		return value.Iterator(), nil
+	}
+	return nil, newError(errorToken, "Can only iterate over strings, lists, maps and other iterables.")
+}
+
+// stringIterator yields the characters of a string as strings of one rune each
+type stringIterator struct {
+	text     string
+	position int
+}
+
+func (s *stringIterator) Next() (interface{}, bool) {
+	if s.position >= len(s.text) {
+		return nil, false
+	}
+	_, size := utf8.DecodeRuneInString(s.text[s.position:])
+	character := s.text[s.position : s.position+size]
+	s.position += size
+	return character, true
+}
+
+// listIterator yields the elements of a list. Elements appended while
+// iterating are yielded as well.
+type listIterator struct {
+	list     *List
+	position int
+}
+
+func (l *listIterator) Next() (interface{}, bool) {
+	if l.position >= len(l.list.Elements) {
+		return nil, false
+	}
+	element := l.list.Elements[l.position]
+	l.position++
+	return element, true
+}
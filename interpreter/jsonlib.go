@@ -0,0 +1,335 @@
+package interpreter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// defineJSON defines the json namespace. JSON objects map onto maps with
+// string keys in document order, arrays onto lists and null onto nil.
+func (i *Interpreter) defineJSON() {
+	namespace := i.DefineNamespace("json")
+	namespace.DefineNative("parse", 1, parseJSON)
+	namespace.DefineNative("stringify", 2, stringifyJSON)
+}
+
+func parseJSON(arguments []Value) (Value, error) {
+	text, err := stringArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	parser := &jsonParser{text: text}
+	value, err := parser.value()
+	if err != nil {
+		return nil, err
+	}
+	parser.skipWhitespace()
+	if !parser.isAtEnd() {
+		return nil, parser.error("Unexpected data after JSON value.")
+	}
+	return value, nil
+}
+
+// jsonParser is a recursive descent parser of JSON text. Unlike
+// encoding/json it keeps the order of object keys and reports errors
+// at the exact position they occur at.
+type jsonParser struct {
+	text    string
+	current int
+}
+
+func (p *jsonParser) value() (Value, error) {
+	p.skipWhitespace()
+	if p.isAtEnd() {
+		return nil, p.error("Unexpected end of input.")
+	}
+	switch c := p.text[p.current]; {
+	case c == '{':
+		return p.object()
+	case c == '[':
+		return p.array()
+	case c == '"':
+		return p.string()
+	case c == '-' || c >= '0' && c <= '9':
+		return p.number()
+	}
+	for literal, value := range map[string]Value{"true": true, "false": false, "null": nil} {
+		if strings.HasPrefix(p.text[p.current:], literal) {
+			p.current += len(literal)
+			return value, nil
+		}
+	}
+	return nil, p.unexpected()
+}
+
+func (p *jsonParser) object() (Value, error) {
+	object := NewMap()
+	p.current++
+	if p.match('}') {
+		return object, nil
+	}
+	for {
+		p.skipWhitespace()
+		if p.isAtEnd() || p.text[p.current] != '"' {
+			return nil, p.expected("string key")
+		}
+		key, err := p.string()
+		if err != nil {
+			return nil, err
+		}
+		if !p.match(':') {
+			return nil, p.expected("':' after key")
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		object.set(key, value)
+		if p.match('}') {
+			return object, nil
+		}
+		if !p.match(',') {
+			return nil, p.expected("',' or '}' after value")
+		}
+	}
+}
+
+func (p *jsonParser) array() (Value, error) {
+	elements := make([]interface{}, 0)
+	p.current++
+	if p.match(']') {
+		return NewList(elements), nil
+	}
+	for {
+		element, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+		if p.match(']') {
+			return NewList(elements), nil
+		}
+		if !p.match(',') {
+			return nil, p.expected("',' or ']' after element")
+		}
+	}
+}
+
+// string scans a string literal, leaving unescaping to encoding/json
+func (p *jsonParser) string() (Value, error) {
+	start := p.current
+	p.current++
+	for !p.isAtEnd() && p.text[p.current] != '"' {
+		switch p.text[p.current] {
+		case '\\':
+			p.current++
+		case '\n':
+			return nil, p.error("Unterminated string.")
+		}
+		p.current++
+	}
+	if p.isAtEnd() {
+		return nil, p.error("Unterminated string.")
+	}
+	p.current++
+	var s string
+	if err := json.Unmarshal([]byte(p.text[start:p.current]), &s); err != nil {
+		p.current = start
+		return nil, p.error("Invalid string.")
+	}
+	return s, nil
+}
+
+// number scans a number following the JSON grammar, which is
+// stricter than the one of strconv.ParseFloat
+func (p *jsonParser) number() (Value, error) {
+	start := p.current
+	p.next('-')
+	if !p.next('0') && !p.digits() {
+		return nil, p.unexpected()
+	}
+	if p.next('.') && !p.digits() {
+		return nil, p.unexpected()
+	}
+	if p.next('e') || p.next('E') {
+		if !p.next('+') {
+			p.next('-')
+		}
+		if !p.digits() {
+			return nil, p.unexpected()
+		}
+	}
+	number, err := strconv.ParseFloat(p.text[start:p.current], 64)
+	if err != nil {
+		p.current = start
+		return nil, p.error("Number out of range.")
+	}
+	return number, nil
+}
+
+// digits consumes a sequence of digits and reports whether there was any
+func (p *jsonParser) digits() bool {
+	start := p.current
+	for !p.isAtEnd() && p.text[p.current] >= '0' && p.text[p.current] <= '9' {
+		p.current++
+	}
+	return p.current > start
+}
+
+// match consumes the character c, which may be preceded by whitespace
+func (p *jsonParser) match(c byte) bool {
+	p.skipWhitespace()
+	return p.next(c)
+}
+
+// next consumes the character c if it comes next
+func (p *jsonParser) next(c byte) bool {
+	if p.isAtEnd() || p.text[p.current] != c {
+		return false
+	}
+	p.current++
+	return true
+}
+
+func (p *jsonParser) skipWhitespace() {
+	for !p.isAtEnd() && strings.IndexByte(" \t\r\n", p.text[p.current]) >= 0 {
+		p.current++
+	}
+}
+
+func (p *jsonParser) isAtEnd() bool {
+	return p.current >= len(p.text)
+}
+
+func (p *jsonParser) expected(what string) error {
+	if p.isAtEnd() {
+		return p.error("Expected " + what + " but reached end of input.")
+	}
+	return p.error("Expected " + what + ".")
+}
+
+func (p *jsonParser) unexpected() error {
+	if p.isAtEnd() {
+		return p.error("Unexpected end of input.")
+	}
+	c, _ := utf8.DecodeRuneInString(p.text[p.current:])
+	return p.error(fmt.Sprintf("Unexpected character %q.", c))
+}
+
+// error describes an error at the current position by line and column
+func (p *jsonParser) error(message string) error {
+	before := p.text[:p.current]
+	line := strings.Count(before, "\n") + 1
+	column := utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:]) + 1
+	return fmt.Errorf("Invalid JSON at line %d, column %d: %s", line, column, message)
+}
+
+// stringifyJSON encodes a value as JSON. The indent is nil for compact
+// output, a number of spaces or a string to indent nested values with.
+func stringifyJSON(arguments []Value) (Value, error) {
+	var buffer bytes.Buffer
+	err := encodeJSON(&buffer, arguments[0], make(map[interface{}]bool))
+	if err != nil {
+		return nil, err
+	}
+	var indent string
+	switch value := arguments[1].(type) {
+	case nil:
+		return buffer.String(), nil
+	case float64:
+		if !isInteger(value) || value < 0 {
+			return nil, errors.New("Indent must not be a negative or fractional number.")
+		}
+		indent = strings.Repeat(" ", int(value))
+	case string:
+		indent = value
+	default:
+		return nil, errors.New("Indent must be nil, a number or a string.")
+	}
+	var indented bytes.Buffer
+	err = json.Indent(&indented, buffer.Bytes(), "", indent)
+	if err != nil {
+		return nil, err
+	}
+	return indented.String(), nil
+}
+
+// encodeJSON writes the compact encoding of value. visiting holds the
+// lists and maps being encoded, to reject values containing themselves.
+func encodeJSON(buffer *bytes.Buffer, value Value, visiting map[interface{}]bool) error {
+	switch value := value.(type) {
+	case nil, bool:
+		encoded, _ := json.Marshal(value)
+		buffer.Write(encoded)
+		return nil
+	case string:
+		encoder := json.NewEncoder(buffer)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		// Encode terminates the value with a newline
+		buffer.Truncate(buffer.Len() - 1)
+		return nil
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return fmt.Errorf("Cannot encode %s as JSON.", stringify(value))
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buffer.Write(encoded)
+		return nil
+	case *List:
+		if visiting[value] {
+			return errors.New("Cannot encode a list containing itself as JSON.")
+		}
+		visiting[value] = true
+		defer delete(visiting, value)
+		buffer.WriteByte('[')
+		for index, element := range value.Elements {
+			if index > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := encodeJSON(buffer, element, visiting); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+		return nil
+	case *Map:
+		if visiting[value] {
+			return errors.New("Cannot encode a map containing itself as JSON.")
+		}
+		visiting[value] = true
+		defer delete(visiting, value)
+		buffer.WriteByte('{')
+		for index, key := range value.Keys() {
+			name, ok := key.(string)
+			if !ok {
+				return fmt.Errorf("Cannot encode map key %s as JSON, keys must be strings.", stringifyElement(key))
+			}
+			if index > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := encodeJSON(buffer, name, visiting); err != nil {
+				return err
+			}
+			buffer.WriteByte(':')
+			if err := encodeJSON(buffer, value.values[key], visiting); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+		return nil
+	}
+	name, _ := typeName([]Value{value})
+	return fmt.Errorf("Cannot encode a value of type %s as JSON.", name)
+}
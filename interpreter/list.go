@@ -0,0 +1,55 @@
+package interpreter
+
+import (
+	"github.com/lmaraite/golox/token"
+)
+
+// List is the runtime value of a list. Lists are passed around by reference,
+// so every variable holding a list sees modifications made through another one.
+type List struct {
+	Elements []interface{}
+}
+
+func NewList(elements []interface{}) *List {
+	return &List{
+		Elements: elements,
+	}
+}
+
+func (l *List) Get(bracket token.Token, index interface{}) (interface{}, error) {
+	position, err := l.position(bracket, index)
+	if err != nil {
+		return nil, err
+	}
+	return l.Elements[position], nil
+}
+
+func (l *List) Set(bracket token.Token, index interface{}, value interface{}) error {
+	position, err := l.position(bracket, index)
+	if err != nil {
+		return err
+	}
+	l.Elements[position] = value
+	return nil
+}
+
+func (l *List) Iterator() Iterator {
+	return &listIterator{list: l}
+}
+
+// position converts an index into a position within the list's elements.
+// Negative indices count from the end of the list.
+func (l *List) position(bracket token.Token, index interface{}) (int, error) {
+	number, ok := index.(float64)
+	if !ok || !isInteger(number) {
+		return 0, newError(bracket, "List index must be an integer.")
+	}
+	position := int(number)
+	if position < 0 {
+		position += len(l.Elements)
+	}
+	if position < 0 || position >= len(l.Elements) {
+		return 0, newError(bracket, "List index out of range.")
+	}
+	return position, nil
+}
@@ -0,0 +1,71 @@
+package interpreter
+
+import (
+	"math"
+
+	"github.com/lmaraite/golox/token"
+)
+
+// Map is the runtime value of a map. Like lists, maps are passed around by
+// reference. Its entries are kept in insertion order, so iterating and
+// printing a map is deterministic.
+type Map struct {
+	keys   []interface{}
+	values map[interface{}]interface{}
+}
+
+func NewMap() *Map {
+	return &Map{
+		keys:   make([]interface{}, 0),
+		values: make(map[interface{}]interface{}),
+	}
+}
+
+// Get returns the value stored for key, or nil if there is none
+func (m *Map) Get(bracket token.Token, key interface{}) (interface{}, error) {
+	if !isHashable(key) {
+		return nil, newError(bracket, "Map key must be a string, number, boolean, enum member or nil.")
+	}
+	return m.values[key], nil
+}
+
+func (m *Map) Set(bracket token.Token, key interface{}, value interface{}) error {
+	if !isHashable(key) {
+		return newError(bracket, "Map key must be a string, number, boolean, enum member or nil.")
+	}
+	m.set(key, value)
+	return nil
+}
+
+// set stores value for a key already known to be hashable
+func (m *Map) set(key interface{}, value interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Keys returns the keys of the map in insertion order
+func (m *Map) Keys() []interface{} {
+	return m.keys
+}
+
+func (m *Map) Len() int {
+	return len(m.keys)
+}
+
+// Iterator returns an iterator over the keys of the map in insertion order
+func (m *Map) Iterator() Iterator {
+	return &listIterator{list: NewList(m.keys)}
+}
+
+// isHashable checks whether a value can be used as a map key
+func isHashable(value interface{}) bool {
+	switch value := value.(type) {
+	case nil, bool, string, *EnumMember:
+		return true
+	case float64:
+		return !math.IsNaN(value)
+	}
+	return false
+}
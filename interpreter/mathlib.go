@@ -0,0 +1,91 @@
+package interpreter
+
+import (
+	"errors"
+	"math"
+)
+
+// defineMath defines the math namespace backed by Go's math package
+func (i *Interpreter) defineMath() {
+	namespace := i.DefineNamespace("math")
+	namespace.Define("pi", math.Pi)
+	namespace.Define("e", math.E)
+	namespace.Define("inf", math.Inf(1))
+
+	namespace.DefineNative("floor", 1, mathFunction(math.Floor))
+	namespace.DefineNative("ceil", 1, mathFunction(math.Ceil))
+	namespace.DefineNative("round", 1, mathFunction(math.Round))
+	namespace.DefineNative("abs", 1, mathFunction(math.Abs))
+	namespace.DefineNative("sqrt", 1, mathFunction(math.Sqrt))
+	namespace.DefineNative("log", 1, mathFunction(math.Log))
+	namespace.DefineNative("sin", 1, mathFunction(math.Sin))
+	namespace.DefineNative("cos", 1, mathFunction(math.Cos))
+	namespace.DefineNative("tan", 1, mathFunction(math.Tan))
+	namespace.DefineNative("pow", 2, pow)
+	namespace.DefineNative("min", -1, extremum(math.Min))
+	namespace.DefineNative("max", -1, extremum(math.Max))
+	namespace.DefineNative("isNaN", 1, isNaN)
+	namespace.DefineNative("isInf", 1, isInf)
+}
+
+// mathFunction turns a Go function of one number into a native
+func mathFunction(function func(float64) float64) NativeFunction {
+	return func(arguments []Value) (Value, error) {
+		number, err := numberArgument(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		return function(number), nil
+	}
+}
+
+func pow(arguments []Value) (Value, error) {
+	base, err := numberArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := numberArgument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	return math.Pow(base, exponent), nil
+}
+
+// extremum returns a native reducing any positive number of
+// numbers with a function like math.Min
+func extremum(function func(float64, float64) float64) NativeFunction {
+	return func(arguments []Value) (Value, error) {
+		if len(arguments) == 0 {
+			return nil, errors.New("Expected at least 1 argument but got 0.")
+		}
+		result, err := numberArgument(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		for index := 1; index < len(arguments); index++ {
+			number, err := numberArgument(arguments, index)
+			if err != nil {
+				return nil, err
+			}
+			result = function(result, number)
+		}
+		return result, nil
+	}
+}
+
+func isNaN(arguments []Value) (Value, error) {
+	number, err := numberArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return math.IsNaN(number), nil
+}
+
+// isInf checks whether a number is positive or negative infinity
+func isInf(arguments []Value) (Value, error) {
+	number, err := numberArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return math.IsInf(number, 0), nil
+}
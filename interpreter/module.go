@@ -0,0 +1,143 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lmaraite/golox/environment"
+	"github.com/lmaraite/golox/lexer"
+	"github.com/lmaraite/golox/parser"
+	"github.com/lmaraite/golox/token"
+)
+
+// Namespace exposes the global variables of a module
+type Namespace struct {
+	Name string
+	env  *environment.Environment
+}
+
+// Get returns the global variable of the module with the given name
+func (n *Namespace) Get(name token.Token) (interface{}, error) {
+	if !n.env.Contains(name.Lexeme) {
+		return nil, newError(name, "Undefined property '"+name.Lexeme+"' of namespace '"+n.Name+"'.")
+	}
+	return n.env.Get(name)
+}
+
+// DefineNamespace creates a global namespace that host code can populate with
+// natives and constants, like the namespaces of the standard library
+func (i *Interpreter) DefineNamespace(name string) *Namespace {
+	namespace := &Namespace{Name: name, env: environment.NewEmptyEnvironment()}
+	i.globals.Define(name, namespace)
+	return namespace
+}
+
+// Define binds a value in the namespace
+func (n *Namespace) Define(name string, value Value) {
+	n.env.Define(name, value)
+}
+
+// DefineNative makes a Go function callable by scripts as a member of the namespace
+func (n *Namespace) DefineNative(name string, arity int, function NativeFunction) {
+	n.env.Define(name, NewNative(n.Name+"."+name, arity, function))
+}
+
+// importModule returns the namespace of the module at path. A module is
+// only executed the first time it is imported.
+func (i *Interpreter) importModule(keyword token.Token, path string) (*Namespace, error) {
+	resolved, err := i.resolveModule(path)
+	if err != nil {
+		return nil, newError(keyword, err.Error())
+	}
+	for index, importing := range i.importing {
+		if importing == resolved {
+			return nil, newError(keyword, "Import cycle: "+importChain(append(i.importing[index:], resolved))+".")
+		}
+	}
+	if namespace, ok := i.modules[resolved]; ok {
+		return namespace, nil
+	}
+	namespace, err := i.loadModule(keyword, resolved)
+	if err != nil {
+		return nil, err
+	}
+	i.modules[resolved] = namespace
+	return namespace, nil
+}
+
+// resolveModule looks for the module first relative to the importing file,
+// then in each directory listed in GOLOX_PATH
+func (i *Interpreter) resolveModule(path string) (string, error) {
+	var candidates []string
+	if filepath.IsAbs(path) {
+		candidates = []string{path}
+	} else {
+		directory := "."
+		if i.file != "" {
+			directory = filepath.Dir(i.file)
+		}
+		candidates = append(candidates, filepath.Join(directory, path))
+		for _, directory := range filepath.SplitList(os.Getenv("GOLOX_PATH")) {
+			if directory != "" {
+				candidates = append(candidates, filepath.Join(directory, path))
+			}
+		}
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return filepath.Abs(candidate)
+		}
+	}
+	return "", fmt.Errorf("Cannot find module '%s'.", path)
+}
+
+// loadModule executes the module at path in its own top-level environment
+func (i *Interpreter) loadModule(keyword token.Token, path string) (*Namespace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, newError(keyword, fmt.Sprintf("Cannot read module '%s': %s", path, err))
+	}
+	source := string(data)
+	tokens, err := lexer.NewLexer(source).ScanTokens(source)
+	if err != nil {
+		return nil, newError(keyword, fmt.Sprintf("In module '%s': %s", path, err))
+	}
+	statements, err := parser.NewParser(tokens).Parse()
+	if err != nil {
+		return nil, newError(keyword, fmt.Sprintf("In module '%s': %s", path, err))
+	}
+
+	previousEnv, previousFile := i.env, i.file
+	env := environment.NewEnvironment(i.globals)
+	i.env, i.file = env, path
+	i.importing = append(i.importing, path)
+	err = i.Interpret(statements)
+	i.env, i.file = previousEnv, previousFile
+	i.importing = i.importing[:len(i.importing)-1]
+	var exception *thrown
+	if errors.As(err, &exception) {
+		// Thrown values reach the importer unchanged, so that it can catch them
+		return nil, err
+	} else if err != nil {
+		return nil, newError(keyword, fmt.Sprintf("In module '%s': %s", path, err))
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return &Namespace{Name: name, env: env}, nil
+}
+
+// importChain formats the paths of an import cycle relative to the working directory
+func importChain(paths []string) string {
+	workingDirectory, _ := os.Getwd()
+	names := make([]string, len(paths))
+	for index, path := range paths {
+		names[index] = path
+		if relative, err := filepath.Rel(workingDirectory, path); err == nil {
+			names[index] = relative
+		}
+	}
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,58 @@
+package interpreter
+
+import (
+	"fmt"
+)
+
+// Value is a runtime value of a script: nil, a bool, a float64, a string
+// or one of the runtime types of this package like *List or *Map
+type Value = interface{}
+
+// Callable is implemented by values that can be called from scripts
+type Callable interface {
+	// Arity returns the number of arguments expected,
+	// or -1 if any number of arguments is accepted
+	Arity() int
+	Call(arguments []Value) (Value, error)
+}
+
+// NativeFunction implements a native in Go. An error it returns is
+// raised as a runtime error at the call site.
+type NativeFunction func(arguments []Value) (Value, error)
+
+// Native is a function implemented in Go that can be called from scripts
+type Native struct {
+	name     string
+	arity    int
+	function NativeFunction
+}
+
+func NewNative(name string, arity int, function NativeFunction) *Native {
+	return &Native{
+		name:     name,
+		arity:    arity,
+		function: function,
+	}
+}
+
+func (n *Native) Arity() int {
+	return n.arity
+}
+
+func (n *Native) Call(arguments []Value) (Value, error) {
+	result, err := n.function(arguments)
+	if err != nil {
+		if _, ok := caught(err); ok {
+			return nil, err
+		}
+		// Name the native in errors raised by Go code
+		return nil, fmt.Errorf("%s: %s", n.name, err)
+	}
+	return result, nil
+}
+
+// DefineNative makes a Go function callable by scripts under the given global
+// name. The arity is checked before each call, -1 accepts any number of arguments.
+func (i *Interpreter) DefineNative(name string, arity int, function NativeFunction) {
+	i.globals.Define(name, NewNative(name, arity, function))
+}
@@ -0,0 +1,86 @@
+package interpreter
+
+import (
+	"errors"
+)
+
+// SetSeed seeds the generator of the random namespace, so that
+// runs with the same seed produce the same sequence of values
+func (i *Interpreter) SetSeed(seed int64) {
+	i.random.Seed(seed)
+}
+
+// defineRandom defines the random namespace
+func (i *Interpreter) defineRandom() {
+	namespace := i.DefineNamespace("random")
+	namespace.DefineNative("seed", 1, i.seed)
+	namespace.DefineNative("float", 2, i.randomFloat)
+	namespace.DefineNative("int", 2, i.randomInt)
+	namespace.DefineNative("choice", 1, i.choice)
+	namespace.DefineNative("shuffle", 1, i.shuffle)
+}
+
+func (i *Interpreter) seed(arguments []Value) (Value, error) {
+	seed, err := integerArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	i.SetSeed(int64(seed))
+	return nil, nil
+}
+
+// randomFloat returns a number from min up to but excluding max
+func (i *Interpreter) randomFloat(arguments []Value) (Value, error) {
+	min, err := numberArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	max, err := numberArgument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	if min > max {
+		return nil, errors.New("Minimum must not be greater than maximum.")
+	}
+	return min + i.random.Float64()*(max-min), nil
+}
+
+// randomInt returns an integer from min up to and including max
+func (i *Interpreter) randomInt(arguments []Value) (Value, error) {
+	min, err := integerArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	max, err := integerArgument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	if min > max {
+		return nil, errors.New("Minimum must not be greater than maximum.")
+	}
+	return float64(min + int(i.random.Int63n(int64(max-min)+1))), nil
+}
+
+// choice returns a random element of a list
+func (i *Interpreter) choice(arguments []Value) (Value, error) {
+	list, ok := arguments[0].(*List)
+	if !ok {
+		return nil, errors.New("Argument 1 must be a list.")
+	}
+	if len(list.Elements) == 0 {
+		return nil, errors.New("Cannot choose from an empty list.")
+	}
+	return list.Elements[i.random.Intn(len(list.Elements))], nil
+}
+
+// shuffle shuffles the elements of a list in place
+func (i *Interpreter) shuffle(arguments []Value) (Value, error) {
+	list, ok := arguments[0].(*List)
+	if !ok {
+		return nil, errors.New("Argument 1 must be a list.")
+	}
+	i.random.Shuffle(len(list.Elements), func(a, b int) {
+		list.Elements[a], list.Elements[b] = list.Elements[b], list.Elements[a]
+	})
+	return nil, nil
+}
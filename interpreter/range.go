@@ -0,0 +1,55 @@
+package interpreter
+
+import (
+	"math"
+)
+
+// Range is the runtime value of a range expression. It is lazy, its
+// elements are only computed while it is iterated.
+type Range struct {
+	Start     float64
+	End       float64
+	Step      float64
+	Inclusive bool
+	// Stepped is true if the step was given explicitly
+	Stepped bool
+}
+
+// Contains checks whether number lies within the range. If a step was
+// given, number also has to be one of the elements of the range.
+func (r Range) Contains(number float64) bool {
+	if !r.inBounds(number) {
+		return false
+	}
+	return !r.Stepped || math.Mod(number-r.Start, r.Step) == 0
+}
+
+// inBounds checks whether number lies between the start and end of the range
+// in the direction of its step
+func (r Range) inBounds(number float64) bool {
+	if r.Step > 0 {
+		return number >= r.Start && (number < r.End || r.Inclusive && number == r.End)
+	}
+	return number <= r.Start && (number > r.End || r.Inclusive && number == r.End)
+}
+
+func (r Range) Iterator() Iterator {
+	return &rangeIterator{r: r}
+}
+
+// rangeIterator yields the elements of a range. Each element is computed
+// from the start instead of summing up steps to avoid accumulating
+// rounding errors.
+type rangeIterator struct {
+	r     Range
+	count float64
+}
+
+func (r *rangeIterator) Next() (interface{}, bool) {
+	number := r.r.Start + r.count*r.r.Step
+	if !r.r.inBounds(number) {
+		return nil, false
+	}
+	r.count++
+	return number, true
+}
@@ -0,0 +1,104 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+)
+
+// defineRegex defines the regex namespace. Patterns use the RE2
+// syntax of Go's regexp package.
+func (i *Interpreter) defineRegex() {
+	namespace := i.DefineNamespace("regex")
+	namespace.DefineNative("match", 2, i.regexMatch)
+	namespace.DefineNative("find_all", 2, i.regexFindAll)
+	namespace.DefineNative("replace", 3, i.regexReplace)
+	namespace.DefineNative("split", 2, i.regexSplit)
+}
+
+// maxCachedPatterns is the number of compiled patterns
+// after which the cache is cleared
+const maxCachedPatterns = 100
+
+// compiledPattern returns the compiled pattern given as first argument
+// and the text given as second argument
+func (i *Interpreter) compiledPattern(arguments []Value) (*regexp.Regexp, string, error) {
+	source, err := stringArgument(arguments, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	text, err := stringArgument(arguments, 1)
+	if err != nil {
+		return nil, "", err
+	}
+	if pattern, ok := i.patterns[source]; ok {
+		return pattern, text, nil
+	}
+	pattern, err := regexp.Compile(source)
+	var syntaxError *syntax.Error
+	if errors.As(err, &syntaxError) {
+		return nil, "", fmt.Errorf("Invalid pattern %s: %s.", strconv.Quote(source), syntaxError.Code)
+	} else if err != nil {
+		return nil, "", err
+	}
+	if len(i.patterns) == maxCachedPatterns {
+		// Patterns built from input could otherwise fill the cache without limit
+		i.patterns = make(map[string]*regexp.Regexp)
+	}
+	i.patterns[source] = pattern
+	return pattern, text, nil
+}
+
+// regexMatch returns a list of the first match and its groups, or nil if the
+// text does not match. Groups that did not participate are nil.
+func (i *Interpreter) regexMatch(arguments []Value) (Value, error) {
+	pattern, text, err := i.compiledPattern(arguments)
+	if err != nil {
+		return nil, err
+	}
+	indices := pattern.FindStringSubmatchIndex(text)
+	if indices == nil {
+		return nil, nil
+	}
+	elements := make([]interface{}, len(indices)/2)
+	for group := range elements {
+		if start := indices[2*group]; start >= 0 {
+			elements[group] = text[start:indices[2*group+1]]
+		}
+	}
+	return NewList(elements), nil
+}
+
+// regexFindAll returns a list of all matches
+func (i *Interpreter) regexFindAll(arguments []Value) (Value, error) {
+	pattern, text, err := i.compiledPattern(arguments)
+	if err != nil {
+		return nil, err
+	}
+	return stringList(pattern.FindAllString(text, -1)), nil
+}
+
+// regexReplace replaces all matches, expanding $1 or ${name} in the
+// replacement to the text of the group
+func (i *Interpreter) regexReplace(arguments []Value) (Value, error) {
+	pattern, text, err := i.compiledPattern(arguments)
+	if err != nil {
+		return nil, err
+	}
+	replacement, err := stringArgument(arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+	return pattern.ReplaceAllString(text, replacement), nil
+}
+
+// regexSplit splits the text at each match into a list
+func (i *Interpreter) regexSplit(arguments []Value) (Value, error) {
+	pattern, text, err := i.compiledPattern(arguments)
+	if err != nil {
+		return nil, err
+	}
+	return stringList(pattern.Split(text, -1)), nil
+}
@@ -0,0 +1,52 @@
+package interpreter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SetStdin sets the reader the input, readLine and readAll natives read from
+func (i *Interpreter) SetStdin(reader io.Reader) {
+	i.stdin = bufio.NewReader(reader)
+}
+
+// defineStdin defines the natives reading standard input
+func (i *Interpreter) defineStdin() {
+	i.DefineNative("input", 1, i.input)
+	i.DefineNative("readLine", 0, i.readLine)
+	i.DefineNative("readAll", 0, i.readAll)
+}
+
+// input prints a prompt without a trailing newline and reads a line
+func (i *Interpreter) input(arguments []Value) (Value, error) {
+	fmt.Print(stringify(arguments[0]))
+	return i.readLine(arguments)
+}
+
+// readLine returns the next line without its line ending, or nil at EOF
+func (i *Interpreter) readLine(arguments []Value) (Value, error) {
+	line, err := i.stdin.ReadString('\n')
+	if err == io.EOF {
+		if line == "" {
+			return nil, nil
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), nil
+}
+
+// readAll returns everything left to read, or nil at EOF
+func (i *Interpreter) readAll(arguments []Value) (Value, error) {
+	data, err := io.ReadAll(i.stdin)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return string(data), nil
+}
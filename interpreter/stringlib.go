@@ -0,0 +1,222 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// defineStrings defines the string namespace. Its natives count
+// positions and lengths in characters (runes), not in bytes.
+func (i *Interpreter) defineStrings() {
+	namespace := i.DefineNamespace("string")
+	namespace.DefineNative("length", 1, stringLength)
+	namespace.DefineNative("slice", 3, slice)
+	namespace.DefineNative("indexOf", 2, indexOf)
+	namespace.DefineNative("split", 2, split)
+	namespace.DefineNative("join", 2, join)
+	namespace.DefineNative("trim", 1, stringFunction(strings.TrimSpace))
+	namespace.DefineNative("upper", 1, stringFunction(strings.ToUpper))
+	namespace.DefineNative("lower", 1, stringFunction(strings.ToLower))
+	namespace.DefineNative("replace", 3, replace)
+	namespace.DefineNative("startsWith", 2, stringPredicate(strings.HasPrefix))
+	namespace.DefineNative("endsWith", 2, stringPredicate(strings.HasSuffix))
+	namespace.DefineNative("repeat", 2, repeat)
+	namespace.DefineNative("charCode", 2, charCode)
+	namespace.DefineNative("fromCharCode", 1, fromCharCode)
+}
+
+// stringFunction turns a Go function transforming a string into a native
+func stringFunction(function func(string) string) NativeFunction {
+	return func(arguments []Value) (Value, error) {
+		s, err := stringArgument(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		return function(s), nil
+	}
+}
+
+// stringPredicate turns a Go function checking two strings into a native
+func stringPredicate(predicate func(string, string) bool) NativeFunction {
+	return func(arguments []Value) (Value, error) {
+		s, err := stringArgument(arguments, 0)
+		if err != nil {
+			return nil, err
+		}
+		other, err := stringArgument(arguments, 1)
+		if err != nil {
+			return nil, err
+		}
+		return predicate(s, other), nil
+	}
+}
+
+func stringLength(arguments []Value) (Value, error) {
+	s, err := stringArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return float64(utf8.RuneCountInString(s)), nil
+}
+
+// slice returns the characters from start up to but excluding end.
+// Negative positions count from the end of the string and are clamped
+// to its bounds like in most scripting languages.
+func slice(arguments []Value) (Value, error) {
+	s, err := stringArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	start, err := integerArgument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	end, err := integerArgument(arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+	runes := []rune(s)
+	start, end = clamp(start, len(runes)), clamp(end, len(runes))
+	if start >= end {
+		return "", nil
+	}
+	return string(runes[start:end]), nil
+}
+
+// clamp converts a possibly negative position into one within 0 and length
+func clamp(position int, length int) int {
+	if position < 0 {
+		position += length
+	}
+	if position < 0 {
+		return 0
+	}
+	if position > length {
+		return length
+	}
+	return position
+}
+
+// indexOf returns the character position of the first occurrence of a substring or -1
+func indexOf(arguments []Value) (Value, error) {
+	s, err := stringArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	substring, err := stringArgument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	index := strings.Index(s, substring)
+	if index < 0 {
+		return -1.0, nil
+	}
+	return float64(utf8.RuneCountInString(s[:index])), nil
+}
+
+// split splits a string at each separator into a list.
+// An empty separator splits it into its characters.
+func split(arguments []Value) (Value, error) {
+	s, err := stringArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	separator, err := stringArgument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	return stringList(strings.Split(s, separator)), nil
+}
+
+// join concatenates the elements of a list of strings, putting a separator between them
+func join(arguments []Value) (Value, error) {
+	list, ok := arguments[0].(*List)
+	if !ok {
+		return nil, errors.New("Argument 1 must be a list.")
+	}
+	separator, err := stringArgument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]string, len(list.Elements))
+	for index, element := range list.Elements {
+		part, ok := element.(string)
+		if !ok {
+			return nil, fmt.Errorf("List element %d must be a string.", index)
+		}
+		parts[index] = part
+	}
+	return strings.Join(parts, separator), nil
+}
+
+// replace replaces all occurrences of a substring
+func replace(arguments []Value) (Value, error) {
+	s, err := stringArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	old, err := stringArgument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	replacement, err := stringArgument(arguments, 2)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ReplaceAll(s, old, replacement), nil
+}
+
+// maxRepeatLength is the length in bytes up to which repeat builds strings
+const maxRepeatLength = 1 << 26
+
+func repeat(arguments []Value) (Value, error) {
+	s, err := stringArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	count, err := integerArgument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	if count < 0 {
+		return nil, errors.New("Count must not be negative.")
+	}
+	if len(s) > 0 && count > maxRepeatLength/len(s) {
+		return nil, errors.New("Result too long.")
+	}
+	return strings.Repeat(s, count), nil
+}
+
+// charCode returns the Unicode code point of the character at a position
+func charCode(arguments []Value) (Value, error) {
+	s, err := stringArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	position, err := integerArgument(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	runes := []rune(s)
+	if position < 0 {
+		position += len(runes)
+	}
+	if position < 0 || position >= len(runes) {
+		return nil, errors.New("Position out of range.")
+	}
+	return float64(runes[position]), nil
+}
+
+// fromCharCode returns the character with the given Unicode code point
+func fromCharCode(arguments []Value) (Value, error) {
+	code, err := integerArgument(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	if code < 0 || code > utf8.MaxRune || !utf8.ValidRune(rune(code)) {
+		return nil, errors.New("Argument 1 must be a valid code point.")
+	}
+	return string(rune(code)), nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"unicode"
 
 	"github.com/lmaraite/golox/token"
@@ -15,6 +16,9 @@ type lexer struct {
 	start   int
 	current int
 	line    int
+	// interpolations holds, for every string interpolation we are currently
+	// inside of, the number of braces opened within its expression
+	interpolations []int
 }
 
 func NewLexer(source string) *lexer {
@@ -33,10 +37,16 @@ func newError(line int, message string) error {
 }
 
 func (l *lexer) ScanTokens(source string) ([]token.Token, error) {
+	// Only the first error is reported, later ones may be caused by it
 	var err error
 	for !l.isAtEnd() {
 		l.start = l.current
-		err = l.scanToken()
+		if scanErr := l.scanToken(); err == nil {
+			err = scanErr
+		}
+	}
+	if len(l.interpolations) > 0 && err == nil {
+		err = newError(l.line, "unterminated string interpolation")
 	}
 	l.addToken(token.EOF)
 	return l.tokens, err
@@ -50,13 +60,41 @@ func (l *lexer) scanToken() error {
 	case ')':
 		l.addToken(token.RIGHT_PAREN)
 	case '{':
+		if depth := len(l.interpolations); depth > 0 {
+			l.interpolations[depth-1]++
+		}
 		l.addToken(token.LEFT_BRACE)
+	case '#':
+		if !l.match('{') {
+			return newError(l.line, "expected '{' after '#'")
+		}
+		if depth := len(l.interpolations); depth > 0 {
+			l.interpolations[depth-1]++
+		}
+		l.addToken(token.HASH_LEFT_BRACE)
 	case '}':
+		if depth := len(l.interpolations); depth > 0 {
+			if l.interpolations[depth-1] == 0 {
+				// This brace closes the interpolated expression,
+				// so the string continues after it
+				l.interpolations = l.interpolations[:depth-1]
+				return l.lexString()
+			}
+			l.interpolations[depth-1]--
+		}
 		l.addToken(token.RIGHT_BRACE)
+	case '[':
+		l.addToken(token.LEFT_BRACKET)
+	case ']':
+		l.addToken(token.RIGHT_BRACKET)
 	case ',':
 		l.addToken(token.COMMA)
 	case '.':
-		l.addToken(token.DOT)
+		if l.match('.') {
+			l.lexTwoCharToken('=', token.DOT_DOT, token.DOT_DOT_EQUAL)
+		} else {
+			l.addToken(token.DOT)
+		}
 	case '-':
 		l.addToken(token.MINUS)
 	case '+':
@@ -64,19 +102,50 @@ func (l *lexer) scanToken() error {
 	case ';':
 		l.addToken(token.SEMICOLON)
 	case '*':
-		l.addToken(token.STAR)
+		l.lexTwoCharToken('*', token.STAR, token.STAR_STAR)
+	case '%':
+		l.addToken(token.PERCENT)
+	case '&':
+		l.addToken(token.AMPERSAND)
+	case '|':
+		l.addToken(token.PIPE)
+	case '^':
+		l.addToken(token.CARET)
+	case '~':
+		l.addToken(token.TILDE)
+	case '?':
+		l.addToken(token.QUESTION)
+	case ':':
+		l.addToken(token.COLON)
 	case '!':
-		l.lexTwoCharToken(token.BANG, token.BANG_EQUAL)
+		l.lexTwoCharToken('=', token.BANG, token.BANG_EQUAL)
 	case '=':
-		l.lexTwoCharToken(token.EQUAL, token.EQUAL_EQUAL)
+		if l.match('>') {
+			l.addToken(token.FAT_ARROW)
+		} else {
+			l.lexTwoCharToken('=', token.EQUAL, token.EQUAL_EQUAL)
+		}
 	case '<':
-		l.lexTwoCharToken(token.LESS, token.LESS_EQUAL)
+		if l.match('<') {
+			l.addToken(token.LESS_LESS)
+		} else {
+			l.lexTwoCharToken('=', token.LESS, token.LESS_EQUAL)
+		}
 	case '>':
-		l.lexTwoCharToken(token.GREATER, token.GREATER_EQUAL)
+		if l.match('>') {
+			l.addToken(token.GREATER_GREATER)
+		} else {
+			l.lexTwoCharToken('=', token.GREATER, token.GREATER_EQUAL)
+		}
 	case '/':
 		l.lexSlashOrComment()
 	case '"':
+		if l.peek() == '"' && l.peekNext() == '"' {
+			return l.lexTextBlock()
+		}
 		return l.lexString()
+	case '`':
+		return l.lexRawString()
 	case ' ':
 		break
 	case '\r':
@@ -90,6 +159,7 @@ func (l *lexer) scanToken() error {
 			return l.lexNumber()
 		} else if isAlphaNumeric(c) {
 			l.lexIdentifier()
+			return nil
 		}
 		return newError(l.line, "unexpected character")
 	}
@@ -125,10 +195,15 @@ func (l *lexer) peek() uint8 {
 
 // peekNext returns the next character of the lexer's source string without consuming any character
 func (l *lexer) peekNext() uint8 {
-	if l.current+1 >= len(l.source) {
+	return l.peekAt(1)
+}
+
+// peekAt returns the character offset positions after the current one without consuming any character
+func (l *lexer) peekAt(offset int) uint8 {
+	if l.current+offset >= len(l.source) {
 		return 0
 	}
-	return l.source[l.current+1]
+	return l.source[l.current+offset]
 }
 
 func isDigit(char uint8) bool {
@@ -143,8 +218,23 @@ func isAlphaNumeric(c uint8) bool {
 	return isAlpha(c) || isDigit(c)
 }
 
+// lexString lexes a string literal or the segment of an interpolated string
+// that starts after the opening '"' or the '}' closing an interpolated expression.
+// A segment ending in "${" is added as an INTERPOLATION token, the tokens of the
+// embedded expression follow it.
 func (l *lexer) lexString() error {
 	for l.peek() != '"' && !l.isAtEnd() {
+		if l.peek() == '$' && l.peekNext() == '{' {
+			value := l.source[l.start+1 : l.current]
+			l.advance() // the $
+			l.advance() // the {
+			if l.isEmptyInterpolation() {
+				return newError(l.line, "empty string interpolation")
+			}
+			l.addLiteralToken(token.INTERPOLATION, value)
+			l.interpolations = append(l.interpolations, 0)
+			return nil
+		}
 		if l.peek() == '\n' {
 			l.line++
 		}
@@ -160,6 +250,97 @@ func (l *lexer) lexString() error {
 	return nil
 }
 
+// isEmptyInterpolation checks whether only whitespace follows the
+// "${" just consumed up to the closing '}'
+func (l *lexer) isEmptyInterpolation() bool {
+	offset := 0
+	for c := l.peekAt(offset); c == ' ' || c == '\t' || c == '\r' || c == '\n'; c = l.peekAt(offset) {
+		offset++
+	}
+	return l.peekAt(offset) == '}'
+}
+
+// lexRawString lexes a string delimited by backticks. Its content is taken
+// verbatim, it may span multiple lines and is not interpolated.
+func (l *lexer) lexRawString() error {
+	for l.peek() != '`' && !l.isAtEnd() {
+		if l.peek() == '\n' {
+			l.line++
+		}
+		l.advance()
+	}
+	if l.isAtEnd() {
+		return newError(l.line, "unterminated raw string")
+	}
+	l.advance() // the closing `
+
+	value := l.source[l.start+1 : l.current-1]
+	l.addLiteralToken(token.STRING, value)
+	return nil
+}
+
+// lexTextBlock lexes a multi-line string delimited by triple quotes.
+// Like raw strings it is not interpolated, but the indentation common
+// to all of its lines is removed.
+func (l *lexer) lexTextBlock() error {
+	l.advance() // the second "
+	l.advance() // the third "
+	for !l.isAtEnd() && !(l.peek() == '"' && l.peekNext() == '"' && l.peekAt(2) == '"') {
+		if l.peek() == '\n' {
+			l.line++
+		}
+		l.advance()
+	}
+	if l.isAtEnd() {
+		return newError(l.line, "unterminated text block")
+	}
+	// the closing """
+	l.advance()
+	l.advance()
+	l.advance()
+
+	value := stripIndentation(l.source[l.start+3 : l.current-3])
+	l.addLiteralToken(token.STRING, value)
+	return nil
+}
+
+// stripIndentation removes the whitespace prefix common to all non-blank lines
+// of a text block. A line break directly after the opening delimiter and a blank
+// line holding the closing delimiter are dropped as well.
+func stripIndentation(text string) string {
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "\r"), "\n")
+	lines := strings.Split(text, "\n")
+	if last := len(lines) - 1; last > 0 && strings.TrimSpace(lines[last]) == "" {
+		lines = lines[:last]
+	}
+
+	var indentation string
+	found := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prefix := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if !found {
+			indentation = prefix
+			found = true
+			continue
+		}
+		for !strings.HasPrefix(prefix, indentation) {
+			indentation = indentation[:len(indentation)-1]
+		}
+	}
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = strings.TrimPrefix(line, indentation)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (l *lexer) lexNumber() error {
 	for isDigit(l.peek()) {
 		l.advance()
@@ -203,9 +384,11 @@ func (l *lexer) lexSlashOrComment() {
 	}
 }
 
-func (l *lexer) lexTwoCharToken(tokenType token.TokenType, equalTokenType token.TokenType) {
-	if l.match('=') {
-		l.addToken(equalTokenType)
+// lexTwoCharToken adds twoCharTokenType if the next character is second,
+// otherwise it adds the single character tokenType
+func (l *lexer) lexTwoCharToken(second uint8, tokenType token.TokenType, twoCharTokenType token.TokenType) {
+	if l.match(second) {
+		l.addToken(twoCharTokenType)
 	} else {
 		l.addToken(tokenType)
 	}
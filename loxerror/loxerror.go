@@ -0,0 +1,54 @@
+package loxerror
+
+import (
+	"fmt"
+
+	"github.com/lmaraite/golox/token"
+)
+
+// RuntimeError is an error raised while executing a script. It keeps the
+// token it was raised at, so scripts catching it can inspect its line.
+type RuntimeError struct {
+	Token   token.Token
+	Message string
+}
+
+func NewRuntimeError(errorToken token.Token, message string) *RuntimeError {
+	return &RuntimeError{
+		Token:   errorToken,
+		Message: message,
+	}
+}
+
+func (e *RuntimeError) Error() string {
+	if e.Token.TokenType == token.EOF {
+		return fmt.Sprintf("[line %d] Runtime error at end: %s", e.Token.Line, e.Message)
+	}
+	return fmt.Sprintf("[line %d] Runtime error at '%s': %s", e.Token.Line, e.Token.Lexeme, e.Message)
+}
+
+// AssertionError is raised by a failing assert statement
+type AssertionError struct {
+	Token token.Token
+	// Source is the source text of the asserted condition
+	Source string
+	// Operands shows the evaluated operands if the condition is a comparison
+	Operands string
+	Message  string
+}
+
+// Description describes the failed assertion without its line
+func (e *AssertionError) Description() string {
+	description := "Assertion failed: " + e.Source
+	if e.Operands != "" {
+		description += " (" + e.Operands + ")"
+	}
+	if e.Message != "" {
+		description += ": " + e.Message
+	}
+	return description
+}
+
+func (e *AssertionError) Error() string {
+	return fmt.Sprintf("[line %d] %s", e.Token.Line, e.Description())
+}
@@ -1,31 +1,58 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/lmaraite/golox/interpreter"
 	"github.com/lmaraite/golox/lexer"
 	"github.com/lmaraite/golox/parser"
 )
 
+var disableAssertions = flag.Bool("disable-assertions", false, "skip assert statements")
+var seed = flag.Int64("seed", 0, "seed the random module for reproducible runs")
+var allowedDirectories directories
+
+func init() {
+	flag.Var(&allowedDirectories, "allow-fs", "allow the fs module to access `dir` (may be repeated)")
+}
+
+// directories collects the values of a flag that may be given several times
+type directories []string
+
+func (d *directories) String() string {
+	return strings.Join(*d, ",")
+}
+
+func (d *directories) Set(value string) error {
+	*d = append(*d, value)
+	return nil
+}
+
 func main() {
-	if len(os.Args) > 2 {
-		fmt.Println("Usage: golox [script]")
+	flag.Usage = func() {
+		fmt.Println("Usage: golox [flags] [script]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 1 {
+		flag.Usage()
 		os.Exit(64)
-	} else if len(os.Args) == 2 {
-		runFile(os.Args[1])
+	} else if flag.NArg() == 1 {
+		runFile(flag.Arg(0))
 	}
 }
 
 func runFile(path string) {
 	data, err := os.ReadFile(path)
 	check(err)
-	err = run(string(data))
+	err = run(path, string(data))
 	check(err)
 }
 
-func run(source string) error {
+func run(path string, source string) error {
 	lexer := lexer.NewLexer(source)
 	tokens, err := lexer.ScanTokens(source)
 	if err != nil {
@@ -38,6 +65,22 @@ func run(source string) error {
 	}
 
 	interpreter := interpreter.NewInterpreter()
+	interpreter.SetAssertionsEnabled(!*disableAssertions)
+	err = interpreter.SetScriptPath(path)
+	if err != nil {
+		return err
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			interpreter.SetSeed(*seed)
+		}
+	})
+	for _, directory := range allowedDirectories {
+		err = interpreter.AllowFileSystemRoot(directory)
+		if err != nil {
+			return err
+		}
+	}
 	err = interpreter.Interpret(statements)
 	if err != nil {
 		return err
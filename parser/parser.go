@@ -3,6 +3,7 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/lmaraite/golox/expr"
 	"github.com/lmaraite/golox/stmt"
@@ -12,43 +13,98 @@ import (
 // This is the context-free grammar we can parse with this parser:
 // program        → declaration* EOF ;
 // declaration    → varDecl
+//                | constDecl
+//                | enumDecl
+//                | importDecl
 //                | statement ;
-// varDecl        → "var" IDENTIFIER ( "=" expression )? ";" ;
+// varDecl        → "var" identifiers ( "=" expressions )? ";" ;
+// constDecl      → "const" identifiers "=" expressions ";" ;
+// enumDecl       → "enum" IDENTIFIER "{" ( identifiers ","? )? "}" ;
+// importDecl     → "import" STRING "as" IDENTIFIER ";" ;
+// identifiers    → IDENTIFIER ( "," IDENTIFIER )* ;
+// expressions    → expression ( "," expression )* ;
 // statement      → exprStmt
+//                | assertStmt
+//                | forInStmt
 //			      | ifStmt
+//                | matchStmt
 //                | printStmt
+//                | throwStmt
+//                | tryStmt
 // 				  | whileStmt
 //				  | block ;
+// matchStmt      → "match" "(" expression ")" "{" matchCase* elseCase? "}" ;
+// matchCase      → "case" pattern ( "," pattern )* ( "if" expression )? "=>" statement ;
+// elseCase       → "else" "=>" statement ;
+// pattern        → literal ( ( ".." | "..=" ) literal )?
+//                | IDENTIFIER ( "." property )+
+//                | IDENTIFIER ;
+// literal        → "true" | "false" | "nil" | STRING | "-"? NUMBER ;
+// throwStmt      → "throw" expression ";" ;
+// tryStmt        → "try" block ( catchClause finallyClause? | finallyClause ) ;
+// catchClause    → "catch" "(" IDENTIFIER ")" block ;
+// finallyClause  → "finally" block ;
+// assertStmt     → "assert" expression ( "," expression )? ";" ;
+// forInStmt      → "for" "(" IDENTIFIER "in" expression ")" statement ;
 // whileStmt      → "while" "(" expression ")" statement ;
 // block		  → "{" declaration* "}" ;
-// exprStmt       → expression ";" ;
+// exprStmt       → expression ";"
+//                | call ( "," call )+ "=" expressions ";" ;
 // ifStmt         → "if" "(" expression ")" statement
 //                ( "else" statement )? ;
 // printStmt      → "print" expression ";" ;
 // expression     → assignment ;
-// assignment     → IDENTIFIER "=" assignment
-//                | logic_or ;
+// assignment     → ( IDENTIFIER | call "[" expression "]" ) "=" assignment
+//                | conditional ;
+// conditional    → logic_or ( "?" expression ":" conditional )? ;
 // logic_or       → logic_and ( "or" logic_and )* ;
 // logic_and      → equality ( "and" equality )* ;
-// equality       → comparison ( ( "!=" | "==" ) comparison )* ;
-// comparison     → term ( ( ">" | ">=" | "<" | "<=" ) term )* ;
+// equality       → bit_or ( ( "!=" | "==" ) bit_or )* ;
+// bit_or         → bit_xor ( "|" bit_xor )* ;
+// bit_xor        → bit_and ( "^" bit_and )* ;
+// bit_and        → comparison ( "&" comparison )* ;
+// comparison     → range ( ( ">" | ">=" | "<" | "<=" | "in" ) range )* ;
+// range          → shift ( ( ".." | "..=" ) shift ( "step" shift )? )? ;
+// shift          → term ( ( "<<" | ">>" ) term )* ;
 // term           → factor ( ( "-" | "+" ) factor )* ;
-// factor         → unary ( ( "/" | "*" ) unary )* ;
-// unary          → ( "!" | "-" ) unary
-//                | primary ;
+// factor         → unary ( ( "/" | "*" | "%" ) unary )* ;
+// unary          → ( "!" | "-" | "~" ) unary
+//                | power ;
+// power          → call ( "**" unary )? ;
+// call           → primary ( "(" arguments? ")" | "[" expression "]" | "." property )* ;
+// arguments      → expression ( "," expression )* ;
+// property       → IDENTIFIER | keyword ;
 // primary        → "true" | "false" | "nil"
 //                | NUMBER | STRING
+//                | interpolation
+//                | list
+//                | map
 //                | "(" expression ")"
 //                | IDENTIFIER ;
+// list           → "[" ( expression ( "," expression )* )? "]" ;
+// map            → "#{" ( entry ( "," entry )* )? "}" ;
+// entry          → expression ":" expression ;
+// interpolation  → ( INTERPOLATION expression )+ STRING ;
 type parser struct {
 	tokens  []token.Token
 	current int
+	// scopes holds the variables declared in each enclosing block,
+	// the innermost block last
+	scopes []map[string]declaration
+}
+
+// declaration records how a variable was declared, so assignments
+// to constants can be rejected while parsing
+type declaration struct {
+	name     token.Token
+	constant bool
 }
 
 func NewParser(tokens []token.Token) *parser {
 	return &parser{
 		tokens:  tokens,
 		current: 0,
+		scopes:  []map[string]declaration{make(map[string]declaration)},
 	}
 }
 
@@ -75,23 +131,35 @@ func (p *parser) Parse() ([]stmt.Stmt, error) {
 }
 
 // declaration → varDecl
+//             | constDecl
+//             | enumDecl
+//             | importDecl
 //             | statement ;
 func (p *parser) declaration() (stmt.Stmt, error) {
+	if p.match(token.ENUM) {
+		return p.enumDeclaration()
+	}
+	if p.match(token.IMPORT) {
+		return p.importDeclaration()
+	}
 	if p.match(token.VAR) {
 		return p.varDeclaration()
 	}
+	if p.match(token.CONST) {
+		return p.constDeclaration()
+	}
 	return p.statement()
 }
 
-// varDecl → "var" IDENTIFIER ( "=" expression )? ";" ;
+// varDecl → "var" identifiers ( "=" expressions )? ";" ;
 func (p *parser) varDeclaration() (stmt.Stmt, error) {
-	name, err := p.consume(token.IDENTIFIER, "Expected variable name.")
+	names, err := p.identifiers("Expected variable name.")
 	if err != nil {
 		return nil, err
 	}
-	var initializer expr.Expr
+	var initializers []expr.Expr
 	if p.match(token.EQUAL) {
-		initializer, err = p.expression()
+		initializers, err = p.values(len(names))
 		if err != nil {
 			return nil, err
 		}
@@ -100,21 +168,176 @@ func (p *parser) varDeclaration() (stmt.Stmt, error) {
 	if err != nil {
 		return nil, err
 	}
-	return stmt.Var{Name: name, Initializer: initializer}, nil
+	for _, name := range names {
+		err = p.declare(name, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stmt.Var{Names: names, Initializers: initializers}, nil
+}
+
+// constDecl → "const" identifiers "=" expressions ";" ;
+func (p *parser) constDeclaration() (stmt.Stmt, error) {
+	names, err := p.identifiers("Expected constant name.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.EQUAL, "Expected '=' after constant name, constants must be initialized.")
+	if err != nil {
+		return nil, err
+	}
+	initializers, err := p.values(len(names))
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.SEMICOLON, "Expected ';' after constant declaration.")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		err = p.declare(name, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stmt.Const{Names: names, Initializers: initializers}, nil
+}
+
+// enumDecl → "enum" IDENTIFIER "{" ( identifiers ","? )? "}" ;
+func (p *parser) enumDeclaration() (stmt.Stmt, error) {
+	name, err := p.consume(token.IDENTIFIER, "Expected enum name.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.LEFT_BRACE, "Expected '{' after enum name.")
+	if err != nil {
+		return nil, err
+	}
+	var members []token.Token
+	declared := make(map[string]bool)
+	for !p.check(token.RIGHT_BRACE) && !p.isAtEnd() {
+		member, err := p.consume(token.IDENTIFIER, "Expected enum member name.")
+		if err != nil {
+			return nil, err
+		}
+		if declared[member.Lexeme] {
+			return nil, newError(member, "Duplicate enum member.")
+		}
+		declared[member.Lexeme] = true
+		members = append(members, member)
+		if !p.match(token.COMMA) {
+			break
+		}
+	}
+	_, err = p.consume(token.RIGHT_BRACE, "Expected '}' after enum members.")
+	if err != nil {
+		return nil, err
+	}
+	err = p.declare(name, true)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.Enum{Name: name, Members: members}, nil
+}
+
+// importDecl → "import" STRING "as" IDENTIFIER ";" ;
+func (p *parser) importDeclaration() (stmt.Stmt, error) {
+	keyword := p.previous()
+	path, err := p.consume(token.STRING, "Expected module path after 'import'.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.AS, "Expected 'as' after module path.")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.consume(token.IDENTIFIER, "Expected namespace name after 'as'.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.SEMICOLON, "Expected ';' after import.")
+	if err != nil {
+		return nil, err
+	}
+	err = p.declare(name, true)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.Import{Keyword: keyword, Path: path, Name: name}, nil
+}
+
+// identifiers → IDENTIFIER ( "," IDENTIFIER )* ;
+func (p *parser) identifiers(errMsg string) ([]token.Token, error) {
+	var names []token.Token
+	for {
+		name, err := p.consume(token.IDENTIFIER, errMsg)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if !p.match(token.COMMA) {
+			return names, nil
+		}
+	}
+}
+
+// expressions → expression ( "," expression )* ;
+//
+// values parses the right-hand side of a declaration or assignment
+// to count targets. A single value may be a list unpacked at runtime,
+// otherwise the number of values has to match the number of targets.
+func (p *parser) values(count int) ([]expr.Expr, error) {
+	equals := p.previous()
+	var values []expr.Expr
+	for {
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if !p.match(token.COMMA) {
+			break
+		}
+	}
+	if len(values) != 1 && len(values) != count {
+		return nil, newError(equals, fmt.Sprintf("Expected %d values but got %d.", count, len(values)))
+	}
+	return values, nil
 }
 
 // statement → exprStmt
+//           | assertStmt
+//           | forInStmt
 //			 | ifStmt
+//           | matchStmt
 //           | printStmt
+//           | throwStmt
+//           | tryStmt
 //           | whileStmt
 //           | block ;
 func (p *parser) statement() (stmt.Stmt, error) {
+	if p.match(token.ASSERT) {
+		return p.assertStatement()
+	}
+	if p.match(token.FOR) {
+		return p.forIn()
+	}
 	if p.match(token.IF) {
 		return p.ifStatement()
 	}
+	if p.match(token.MATCH) {
+		return p.matchStatement()
+	}
 	if p.match(token.PRINT) {
 		return p.printStatement()
 	}
+	if p.match(token.THROW) {
+		return p.throwStatement()
+	}
+	if p.match(token.TRY) {
+		return p.tryStatement()
+	}
 	if p.match(token.WHILE) {
 		return p.while()
 	}
@@ -128,6 +351,342 @@ func (p *parser) statement() (stmt.Stmt, error) {
 	return p.expressionStatement()
 }
 
+// assertStmt → "assert" expression ( "," expression )? ";" ;
+func (p *parser) assertStatement() (stmt.Stmt, error) {
+	keyword := p.previous()
+	start := p.current
+	condition, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	source := sourceText(p.tokens[start:p.current])
+	var message expr.Expr
+	if p.match(token.COMMA) {
+		message, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err = p.consume(token.SEMICOLON, "Expected ';' after assertion.")
+	if err != nil {
+		return nil, err
+	}
+	return stmt.Assert{
+		Keyword:   keyword,
+		Condition: condition,
+		Source:    source,
+		Message:   message,
+	}, nil
+}
+
+// sourceText approximates the source code of a sequence of tokens by
+// joining their lexemes, with spaces around everything but brackets,
+// commas and dots
+func sourceText(tokens []token.Token) string {
+	var builder strings.Builder
+	for i, t := range tokens {
+		if i > 0 && needsSpace(tokens[i-1], t) {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(t.Lexeme)
+	}
+	return builder.String()
+}
+
+func needsSpace(previous, current token.Token) bool {
+	switch previous.TokenType {
+	case token.LEFT_PAREN, token.LEFT_BRACKET, token.DOT, token.BANG, token.TILDE:
+		return false
+	}
+	switch current.TokenType {
+	case token.RIGHT_PAREN, token.RIGHT_BRACKET, token.COMMA, token.DOT:
+		return false
+	case token.LEFT_PAREN, token.LEFT_BRACKET:
+		// no space between a callee or indexed value and its bracket
+		switch previous.TokenType {
+		case token.IDENTIFIER, token.STRING, token.RIGHT_PAREN, token.RIGHT_BRACKET:
+			return false
+		}
+	}
+	return true
+}
+
+// forInStmt → "for" "(" IDENTIFIER "in" expression ")" statement ;
+func (p *parser) forIn() (stmt.Stmt, error) {
+	_, err := p.consume(token.LEFT_PAREN, "Expected '(' after 'for'.")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.consume(token.IDENTIFIER, "Expected loop variable name.")
+	if err != nil {
+		return nil, err
+	}
+	in, err := p.consume(token.IN, "Expected 'in' after loop variable.")
+	if err != nil {
+		return nil, err
+	}
+	iterable, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.RIGHT_PAREN, "Expected ')' after for clauses.")
+	if err != nil {
+		return nil, err
+	}
+	p.beginScope()
+	defer p.endScope()
+	err = p.declare(name, false)
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+	return stmt.ForIn{
+		Name:     name,
+		In:       in,
+		Iterable: iterable,
+		Body:     body,
+	}, nil
+}
+
+// matchStmt → "match" "(" expression ")" "{" matchCase* elseCase? "}" ;
+// elseCase  → "else" "=>" statement ;
+func (p *parser) matchStatement() (stmt.Stmt, error) {
+	keyword := p.previous()
+	_, err := p.consume(token.LEFT_PAREN, "Expected '(' after 'match'.")
+	if err != nil {
+		return nil, err
+	}
+	subject, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.RIGHT_PAREN, "Expected ')' after match subject.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.LEFT_BRACE, "Expected '{' before match cases.")
+	if err != nil {
+		return nil, err
+	}
+	match := stmt.Match{Keyword: keyword, Subject: subject}
+	catchAll := false
+	for p.match(token.CASE) {
+		if catchAll {
+			return nil, newError(p.previous(), "Unreachable case after catch-all pattern.")
+		}
+		matchCase, err := p.matchCase()
+		if err != nil {
+			return nil, err
+		}
+		match.Cases = append(match.Cases, matchCase)
+		catchAll = isCatchAll(matchCase)
+	}
+	if p.match(token.ELSE) {
+		if catchAll {
+			return nil, newError(p.previous(), "Unreachable else after catch-all pattern.")
+		}
+		_, err = p.consume(token.FAT_ARROW, "Expected '=>' after 'else'.")
+		if err != nil {
+			return nil, err
+		}
+		match.Else, err = p.statement()
+		if err != nil {
+			return nil, err
+		}
+		if p.check(token.CASE) {
+			return nil, newError(p.peek(), "Unreachable case after catch-all else.")
+		}
+	}
+	_, err = p.consume(token.RIGHT_BRACE, "Expected '}' after match cases.")
+	if err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+// matchCase → "case" pattern ( "," pattern )* ( "if" expression )? "=>" statement ;
+func (p *parser) matchCase() (stmt.MatchCase, error) {
+	var matchCase stmt.MatchCase
+	p.beginScope()
+	defer p.endScope()
+	for {
+		pattern, err := p.pattern()
+		if err != nil {
+			return matchCase, err
+		}
+		matchCase.Patterns = append(matchCase.Patterns, pattern)
+		if !p.match(token.COMMA) {
+			break
+		}
+	}
+	for _, pattern := range matchCase.Patterns {
+		if pattern.Binding == nil {
+			continue
+		}
+		if len(matchCase.Patterns) > 1 {
+			return matchCase, newError(*pattern.Binding, "Binding patterns cannot be combined with other patterns.")
+		}
+		err := p.declare(*pattern.Binding, false)
+		if err != nil {
+			return matchCase, err
+		}
+	}
+	var err error
+	if p.match(token.IF) {
+		matchCase.Guard, err = p.expression()
+		if err != nil {
+			return matchCase, err
+		}
+	}
+	_, err = p.consume(token.FAT_ARROW, "Expected '=>' after case patterns.")
+	if err != nil {
+		return matchCase, err
+	}
+	matchCase.Body, err = p.statement()
+	return matchCase, err
+}
+
+// pattern → literal ( ( ".." | "..=" ) literal )?
+//         | IDENTIFIER ( "." property )+
+//         | IDENTIFIER ;
+func (p *parser) pattern() (stmt.Pattern, error) {
+	if p.match(token.IDENTIFIER) {
+		name := p.previous()
+		if !p.check(token.DOT) {
+			return stmt.Pattern{Binding: &name}, nil
+		}
+		// A qualified name like Color.Red is compared by value
+		var value expr.Expr = expr.Variable{Name: name}
+		for p.match(token.DOT) {
+			property, err := p.property()
+			if err != nil {
+				return stmt.Pattern{}, err
+			}
+			value = expr.Get{Object: value, Name: property}
+		}
+		return stmt.Pattern{Value: value}, nil
+	}
+	value, err := p.literal()
+	if err != nil {
+		return stmt.Pattern{}, err
+	}
+	if p.match(token.DOT_DOT, token.DOT_DOT_EQUAL) {
+		operator := p.previous()
+		end, err := p.literal()
+		if err != nil {
+			return stmt.Pattern{}, err
+		}
+		value = expr.Range{Start: value, Operator: operator, End: end}
+	}
+	return stmt.Pattern{Value: value}, nil
+}
+
+// literal → "true" | "false" | "nil" | STRING | "-"? NUMBER ;
+func (p *parser) literal() (expr.Expr, error) {
+	if p.match(token.TRUE) {
+		return expr.Literal{Value: true}, nil
+	}
+	if p.match(token.FALSE) {
+		return expr.Literal{Value: false}, nil
+	}
+	if p.match(token.NIL) {
+		return expr.Literal{Value: nil}, nil
+	}
+	if p.match(token.NUMBER, token.STRING) {
+		return expr.Literal{Value: p.previous().Literal}, nil
+	}
+	if p.match(token.MINUS) {
+		number, err := p.consume(token.NUMBER, "Expected number after '-' in pattern.")
+		if err != nil {
+			return nil, err
+		}
+		return expr.Literal{Value: -number.Literal.(float64)}, nil
+	}
+	return nil, newError(p.peek(), "Expected pattern.")
+}
+
+// isCatchAll checks whether a match case matches every value,
+// which makes all cases following it unreachable
+func isCatchAll(matchCase stmt.MatchCase) bool {
+	return matchCase.Guard == nil && len(matchCase.Patterns) == 1 && matchCase.Patterns[0].Binding != nil
+}
+
+// throwStmt → "throw" expression ";" ;
+func (p *parser) throwStatement() (stmt.Stmt, error) {
+	keyword := p.previous()
+	value, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.SEMICOLON, "Expected ';' after thrown value.")
+	if err != nil {
+		return nil, err
+	}
+	return stmt.Throw{Keyword: keyword, Value: value}, nil
+}
+
+// tryStmt       → "try" block ( catchClause finallyClause? | finallyClause ) ;
+// catchClause   → "catch" "(" IDENTIFIER ")" block ;
+// finallyClause → "finally" block ;
+func (p *parser) tryStatement() (stmt.Stmt, error) {
+	_, err := p.consume(token.LEFT_BRACE, "Expected '{' after 'try'.")
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+	try := stmt.Try{Body: stmt.Block{Statements: body}}
+	if p.match(token.CATCH) {
+		_, err = p.consume(token.LEFT_PAREN, "Expected '(' after 'catch'.")
+		if err != nil {
+			return nil, err
+		}
+		try.CatchName, err = p.consume(token.IDENTIFIER, "Expected exception variable name.")
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.consume(token.RIGHT_PAREN, "Expected ')' after exception variable.")
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.consume(token.LEFT_BRACE, "Expected '{' after catch clause.")
+		if err != nil {
+			return nil, err
+		}
+		p.beginScope()
+		err = p.declare(try.CatchName, false)
+		if err != nil {
+			return nil, err
+		}
+		catch, err := p.block()
+		p.endScope()
+		if err != nil {
+			return nil, err
+		}
+		try.Catch = &stmt.Block{Statements: catch}
+	}
+	if p.match(token.FINALLY) {
+		_, err = p.consume(token.LEFT_BRACE, "Expected '{' after 'finally'.")
+		if err != nil {
+			return nil, err
+		}
+		finally, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		try.Finally = &stmt.Block{Statements: finally}
+	}
+	if try.Catch == nil && try.Finally == nil {
+		return nil, newError(p.peek(), "Expected 'catch' or 'finally' after try block.")
+	}
+	return try, nil
+}
+
 // whileStmt → "while" "(" expression ")" statement ;
 func (p *parser) while() (stmt.Stmt, error) {
 	_, err := p.consume(token.LEFT_PAREN, "Expected '(' after 'while'.")
@@ -171,7 +730,9 @@ func (p *parser) ifStatement() (stmt.Stmt, error) {
 	if err != nil {
 		return nil, err
 	}
-	if p.match(token.ELSE) {
+	// An else followed by "=>" is the catch-all case of an enclosing match
+	if p.check(token.ELSE) && !p.checkNext(token.FAT_ARROW) {
+		p.advance()
 		elseBranch, err := p.statement()
 		if err != nil {
 			return nil, err
@@ -192,6 +753,8 @@ func (p *parser) ifStatement() (stmt.Stmt, error) {
 // block → "{" declaration* "}" ;
 func (p *parser) block() ([]stmt.Stmt, error) {
 	var statements []stmt.Stmt
+	p.beginScope()
+	defer p.endScope()
 
 	for !p.check(token.RIGHT_BRACE) && !p.isAtEnd() {
 		statement, err := p.declaration()
@@ -215,25 +778,67 @@ func (p *parser) printStatement() (stmt.Print, error) {
 	return stmt.Print{Expression: value}, err
 }
 
-// exprStmt → expression ";" ;
-func (p *parser) expressionStatement() (stmt.Expr, error) {
+// exprStmt → expression ";"
+//          | call ( "," call )+ "=" expressions ";" ;
+func (p *parser) expressionStatement() (stmt.Stmt, error) {
 	expression, err := p.expression()
 	if err != nil {
-		return stmt.Expr{}, err
+		return nil, err
+	}
+	if p.check(token.COMMA) {
+		return p.multipleAssignment(expression)
 	}
 	_, err = p.consume(token.SEMICOLON, "Expect ';' after expression.")
 	return stmt.Expr{Expression: expression}, err
 }
 
+// multipleAssignment parses the remainder of an assignment to several
+// targets, whose first target has already been parsed
+func (p *parser) multipleAssignment(first expr.Expr) (stmt.Stmt, error) {
+	targets := []expr.Expr{first}
+	for p.match(token.COMMA) {
+		target, err := p.call()
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	equals, err := p.consume(token.EQUAL, "Expected '=' after assignment targets.")
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		switch target := target.(type) {
+		case expr.Variable:
+			err = p.checkAssignable(target.Name)
+			if err != nil {
+				return nil, err
+			}
+		case expr.Index:
+		default:
+			return nil, newError(equals, "Invalid assignment target.")
+		}
+	}
+	values, err := p.values(len(targets))
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(token.SEMICOLON, "Expect ';' after assignment.")
+	if err != nil {
+		return nil, err
+	}
+	return stmt.Assign{Targets: targets, Equals: equals, Values: values}, nil
+}
+
 // expression → assignment ;
 func (p *parser) expression() (expr.Expr, error) {
 	return p.assignment()
 }
 
-// assignment     → IDENTIFIER "=" assignment
-//                | logic_or ;
+// assignment     → ( IDENTIFIER | call "[" expression "]" ) "=" assignment
+//                | conditional ;
 func (p *parser) assignment() (expr.Expr, error) {
-	expression, err := p.logicalOr()
+	expression, err := p.conditional()
 	if err != nil {
 		return nil, err
 	}
@@ -245,13 +850,53 @@ func (p *parser) assignment() (expr.Expr, error) {
 		}
 		if variable, ok := expression.(expr.Variable); ok {
 			name := variable.Name
+			err = p.checkAssignable(name)
+			if err != nil {
+				return nil, err
+			}
 			return expr.Assign{Name: name, Value: value}, nil
 		}
+		if index, ok := expression.(expr.Index); ok {
+			return expr.IndexSet{
+				Object:  index.Object,
+				Bracket: index.Bracket,
+				Index:   index.Index,
+				Value:   value,
+			}, nil
+		}
 		return nil, newError(equals, "Invalid assignment target.")
 	}
 	return expression, nil
 }
 
+// conditional → logic_or ( "?" expression ":" conditional )? ;
+func (p *parser) conditional() (expr.Expr, error) {
+	expression, err := p.logicalOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.match(token.QUESTION) {
+		thenBranch, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.consume(token.COLON, "Expected ':' after then branch of conditional expression.")
+		if err != nil {
+			return nil, err
+		}
+		elseBranch, err := p.conditional()
+		if err != nil {
+			return nil, err
+		}
+		return expr.Conditional{
+			Condition:  expression,
+			ThenBranch: thenBranch,
+			ElseBranch: elseBranch,
+		}, nil
+	}
+	return expression, nil
+}
+
 // logic_or → logic_and ( "or" logic_and )* ;
 func (p *parser) logicalOr() (expr.Expr, error) {
 	expression, err := p.logicalAnd()
@@ -294,13 +939,76 @@ func (p *parser) logicalAnd() (expr.Expr, error) {
 	return expression, nil
 }
 
-// equality → comparison ( ( "!=" | "==" ) comparison )* ;
+// equality → bit_or ( ( "!=" | "==" ) bit_or )* ;
 func (p *parser) equality() (expr.Expr, error) {
-	expression, err := p.comparison()
+	expression, err := p.bitwiseOr()
 	if err != nil {
 		return nil, err
 	}
 	for p.match(token.BANG_EQUAL, token.EQUAL_EQUAL) {
+		operator := p.previous()
+		right, err := p.bitwiseOr()
+		if err != nil {
+			return nil, err
+		}
+		expression = expr.Binary{
+			Left:     expression,
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return expression, nil
+}
+
+// bit_or → bit_xor ( "|" bit_xor )* ;
+func (p *parser) bitwiseOr() (expr.Expr, error) {
+	expression, err := p.bitwiseXor()
+	if err != nil {
+		return nil, err
+	}
+	for p.match(token.PIPE) {
+		operator := p.previous()
+		right, err := p.bitwiseXor()
+		if err != nil {
+			return nil, err
+		}
+		expression = expr.Binary{
+			Left:     expression,
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return expression, nil
+}
+
+// bit_xor → bit_and ( "^" bit_and )* ;
+func (p *parser) bitwiseXor() (expr.Expr, error) {
+	expression, err := p.bitwiseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.match(token.CARET) {
+		operator := p.previous()
+		right, err := p.bitwiseAnd()
+		if err != nil {
+			return nil, err
+		}
+		expression = expr.Binary{
+			Left:     expression,
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return expression, nil
+}
+
+// bit_and → comparison ( "&" comparison )* ;
+func (p *parser) bitwiseAnd() (expr.Expr, error) {
+	expression, err := p.comparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.match(token.AMPERSAND) {
 		operator := p.previous()
 		right, err := p.comparison()
 		if err != nil {
@@ -315,13 +1023,66 @@ func (p *parser) equality() (expr.Expr, error) {
 	return expression, nil
 }
 
-// comparison → term ( ( ">" | ">=" | "<" | "<=" ) term )* ;
+// comparison → range ( ( ">" | ">=" | "<" | "<=" | "in" ) range )* ;
 func (p *parser) comparison() (expr.Expr, error) {
+	expression, err := p.rangeExpression()
+	if err != nil {
+		return nil, err
+	}
+	for p.match(token.GREATER, token.GREATER_EQUAL, token.LESS, token.LESS_EQUAL, token.IN) {
+		operator := p.previous()
+		right, err := p.rangeExpression()
+		if err != nil {
+			return nil, err
+		}
+		expression = expr.Binary{
+			Left:     expression,
+			Operator: operator,
+			Right:    right,
+		}
+	}
+	return expression, nil
+}
+
+// range → shift ( ( ".." | "..=" ) shift ( "step" shift )? )? ;
+//
+// "step" is not a keyword, it is only recognized after the end of a range.
+func (p *parser) rangeExpression() (expr.Expr, error) {
+	expression, err := p.shift()
+	if err != nil {
+		return nil, err
+	}
+	if p.match(token.DOT_DOT, token.DOT_DOT_EQUAL) {
+		operator := p.previous()
+		end, err := p.shift()
+		if err != nil {
+			return nil, err
+		}
+		var step expr.Expr
+		if p.check(token.IDENTIFIER) && p.peek().Lexeme == "step" {
+			p.advance()
+			step, err = p.shift()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return expr.Range{
+			Start:    expression,
+			Operator: operator,
+			End:      end,
+			Step:     step,
+		}, nil
+	}
+	return expression, nil
+}
+
+// shift → term ( ( "<<" | ">>" ) term )* ;
+func (p *parser) shift() (expr.Expr, error) {
 	expression, err := p.term()
 	if err != nil {
 		return nil, err
 	}
-	for p.match(token.GREATER, token.GREATER_EQUAL, token.LESS, token.LESS_EQUAL) {
+	for p.match(token.LESS_LESS, token.GREATER_GREATER) {
 		operator := p.previous()
 		right, err := p.term()
 		if err != nil {
@@ -357,13 +1118,13 @@ func (p *parser) term() (expr.Expr, error) {
 	return expression, nil
 }
 
-// factor → unary ( ( "/" | "*" ) unary )* ;
+// factor → unary ( ( "/" | "*" | "%" ) unary )* ;
 func (p *parser) factor() (expr.Expr, error) {
 	expression, err := p.unary()
 	if err != nil {
 		return nil, err
 	}
-	for p.match(token.SLASH, token.STAR) {
+	for p.match(token.SLASH, token.STAR, token.PERCENT) {
 		operator := p.previous()
 		right, err := p.unary()
 		if err != nil {
@@ -378,10 +1139,10 @@ func (p *parser) factor() (expr.Expr, error) {
 	return expression, nil
 }
 
-// unary → ( "!" | "-" ) unary
-//       | primary ;
+// unary → ( "!" | "-" | "~" ) unary
+//       | power ;
 func (p *parser) unary() (expr.Expr, error) {
-	if p.match(token.BANG, token.MINUS) {
+	if p.match(token.BANG, token.MINUS, token.TILDE) {
 		operator := p.previous()
 		right, err := p.unary()
 		if err != nil {
@@ -392,11 +1153,103 @@ func (p *parser) unary() (expr.Expr, error) {
 			Right:    right,
 		}, nil
 	}
-	return p.primary()
+	return p.power()
+}
+
+// power → call ( "**" unary )? ;
+//
+// Exponentiation is right-associative and binds tighter than a unary
+// operator on its left, so -2 ** 2 is -(2 ** 2) and 2 ** -1 is 0.5.
+func (p *parser) power() (expr.Expr, error) {
+	expression, err := p.call()
+	if err != nil {
+		return nil, err
+	}
+	if p.match(token.STAR_STAR) {
+		operator := p.previous()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return expr.Binary{
+			Left:     expression,
+			Operator: operator,
+			Right:    right,
+		}, nil
+	}
+	return expression, nil
+}
+
+// call → primary ( "(" arguments? ")" | "[" expression "]" | "." property )* ;
+func (p *parser) call() (expr.Expr, error) {
+	expression, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if p.match(token.LEFT_PAREN) {
+			expression, err = p.finishCall(expression)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if p.match(token.DOT) {
+			name, err := p.property()
+			if err != nil {
+				return nil, err
+			}
+			expression = expr.Get{Object: expression, Name: name}
+			continue
+		}
+		if !p.match(token.LEFT_BRACKET) {
+			break
+		}
+		bracket := p.previous()
+		index, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.consume(token.RIGHT_BRACKET, "Expected ']' after index.")
+		if err != nil {
+			return nil, err
+		}
+		expression = expr.Index{
+			Object:  expression,
+			Bracket: bracket,
+			Index:   index,
+		}
+	}
+	return expression, nil
+}
+
+// arguments → expression ( "," expression )* ;
+func (p *parser) finishCall(callee expr.Expr) (expr.Expr, error) {
+	arguments := make([]expr.Expr, 0)
+	if !p.check(token.RIGHT_PAREN) {
+		for {
+			argument, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			arguments = append(arguments, argument)
+			if !p.match(token.COMMA) {
+				break
+			}
+		}
+	}
+	paren, err := p.consume(token.RIGHT_PAREN, "Expected ')' after arguments.")
+	if err != nil {
+		return nil, err
+	}
+	return expr.Call{Callee: callee, Paren: paren, Arguments: arguments}, nil
 }
 
 // primary → "true" | "false" | "nil"
 //         | NUMBER | STRING
+//         | interpolation
+//         | list
+//         | map
 //         | "(" expression ")"
 //         | IDENTIFIER ;
 func (p *parser) primary() (expr.Expr, error) {
@@ -414,6 +1267,15 @@ func (p *parser) primary() (expr.Expr, error) {
 			Value: p.previous().Literal,
 		}, nil
 	}
+	if p.match(token.INTERPOLATION) {
+		return p.interpolation()
+	}
+	if p.match(token.LEFT_BRACKET) {
+		return p.list()
+	}
+	if p.match(token.HASH_LEFT_BRACE) {
+		return p.mapLiteral()
+	}
 	if p.match(token.IDENTIFIER) {
 		return expr.Variable{
 			Name: p.previous(),
@@ -430,6 +1292,117 @@ func (p *parser) primary() (expr.Expr, error) {
 	return nil, newError(p.peek(), "Expected expression.")
 }
 
+// interpolation → ( INTERPOLATION expression )+ STRING ;
+func (p *parser) interpolation() (expr.Expr, error) {
+	parts := []expr.Expr{expr.Literal{Value: p.previous().Literal}}
+	for {
+		expression, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, expression)
+		if p.match(token.INTERPOLATION) {
+			parts = append(parts, expr.Literal{Value: p.previous().Literal})
+			continue
+		}
+		end, err := p.consume(token.STRING, "Expected '}' after interpolated expression.")
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, expr.Literal{Value: end.Literal})
+		return expr.Interpolation{Parts: parts}, nil
+	}
+}
+
+// list → "[" ( expression ( "," expression )* )? "]" ;
+func (p *parser) list() (expr.Expr, error) {
+	elements := make([]expr.Expr, 0)
+	if !p.check(token.RIGHT_BRACKET) {
+		for {
+			element, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, element)
+			if !p.match(token.COMMA) {
+				break
+			}
+		}
+	}
+	_, err := p.consume(token.RIGHT_BRACKET, "Expected ']' after list elements.")
+	if err != nil {
+		return nil, err
+	}
+	return expr.List{Elements: elements}, nil
+}
+
+// map   → "#{" ( entry ( "," entry )* )? "}" ;
+// entry → expression ":" expression ;
+func (p *parser) mapLiteral() (expr.Expr, error) {
+	brace := p.previous()
+	keys := make([]expr.Expr, 0)
+	values := make([]expr.Expr, 0)
+	if !p.check(token.RIGHT_BRACE) {
+		for {
+			key, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			_, err = p.consume(token.COLON, "Expected ':' after map key.")
+			if err != nil {
+				return nil, err
+			}
+			value, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+			values = append(values, value)
+			if !p.match(token.COMMA) {
+				break
+			}
+		}
+	}
+	_, err := p.consume(token.RIGHT_BRACE, "Expected '}' after map entries.")
+	if err != nil {
+		return nil, err
+	}
+	return expr.Map{Brace: brace, Keys: keys, Values: values}, nil
+}
+
+func (p *parser) beginScope() {
+	p.scopes = append(p.scopes, make(map[string]declaration))
+}
+
+func (p *parser) endScope() {
+	p.scopes = p.scopes[:len(p.scopes)-1]
+}
+
+// declare records a variable declaration in the innermost scope.
+// Constants cannot be redeclared within the same scope.
+func (p *parser) declare(name token.Token, constant bool) error {
+	scope := p.scopes[len(p.scopes)-1]
+	if previous, ok := scope[name.Lexeme]; ok && previous.constant {
+		return newError(name, fmt.Sprintf("Cannot redeclare constant '%s' declared at line %d.", name.Lexeme, previous.name.Line))
+	}
+	scope[name.Lexeme] = declaration{name: name, constant: constant}
+	return nil
+}
+
+// checkAssignable returns an error if name refers to a constant. Variables
+// not declared in the parsed source are checked at runtime instead.
+func (p *parser) checkAssignable(name token.Token) error {
+	for i := len(p.scopes) - 1; i >= 0; i-- {
+		if declared, ok := p.scopes[i][name.Lexeme]; ok {
+			if declared.constant {
+				return newError(name, fmt.Sprintf("Cannot assign to constant '%s' declared at line %d.", name.Lexeme, declared.name.Line))
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
 // consume consumes a token, and returns it if it matches the tokenType.
 // If not, an error is returned.
 func (p *parser) consume(tokenType token.TokenType, errMsg string) (token.Token, error) {
@@ -439,6 +1412,16 @@ func (p *parser) consume(tokenType token.TokenType, errMsg string) (token.Token,
 	return p.advance(), newError(p.peek(), errMsg)
 }
 
+// property → IDENTIFIER | keyword ;
+// Keywords are allowed as property names, so that a namespace
+// can have members like regex.match.
+func (p *parser) property() (token.Token, error) {
+	if tokenType, ok := token.Keywords[p.peek().Lexeme]; ok && p.check(tokenType) {
+		return p.advance(), nil
+	}
+	return p.consume(token.IDENTIFIER, "Expected property name after '.'.")
+}
+
 // match if the current token has any of the given types. If so
 // it consumes the token and returns true.
 func (p *parser) match(tokenTypes ...token.TokenType) bool {
@@ -459,6 +1442,14 @@ func (p *parser) check(tokenType token.TokenType) bool {
 	return p.peek().TokenType == tokenType
 }
 
+// checkNext checks if the token after the current one is of the given type
+func (p *parser) checkNext(tokenType token.TokenType) bool {
+	if p.isAtEnd() {
+		return false
+	}
+	return p.tokens[p.current+1].TokenType == tokenType
+}
+
 // advance consumes the current token and returns it
 func (p *parser) advance() token.Token {
 	if !p.isAtEnd() {
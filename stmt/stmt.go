@@ -6,10 +6,19 @@ import (
 )
 
 type Visitor interface {
+	VisitAssertStmt(Assert) error
+	VisitAssignStmt(Assign) error
 	VisitBlockStmt(Block) error
+	VisitConstStmt(Const) error
+	VisitEnumStmt(Enum) error
 	VisitExprStmt(Expr) error
+	VisitForInStmt(ForIn) error
 	VisitIfStmt(If) error
+	VisitImportStmt(Import) error
+	VisitMatchStmt(Match) error
 	VisitPrintStmt(Print) error
+	VisitThrowStmt(Throw) error
+	VisitTryStmt(Try) error
 	VisitVarStmt(Var) error
 	VisitWhileStmt(While) error
 }
@@ -18,6 +27,31 @@ type Stmt interface {
 	Accept(v Visitor) error
 }
 
+// Assert is an assert statement. Source holds the source text of the
+// condition for error reporting, Message is nil if none was given.
+type Assert struct {
+	Keyword   token.Token
+	Condition expr.Expr
+	Source    string
+	Message   expr.Expr
+}
+
+func (a Assert) Accept(v Visitor) error {
+	return v.VisitAssertStmt(a)
+}
+
+// Assign assigns to several targets at once, like a, b = b, a;.
+// Targets are variables or index expressions.
+type Assign struct {
+	Targets []expr.Expr
+	Equals  token.Token
+	Values  []expr.Expr
+}
+
+func (a Assign) Accept(v Visitor) error {
+	return v.VisitAssignStmt(a)
+}
+
 type Block struct {
 	Statements []Stmt
 }
@@ -26,6 +60,26 @@ func (b Block) Accept(v Visitor) error {
 	return v.VisitBlockStmt(b)
 }
 
+// Const declares one or more constants. Like for Var, Initializers
+// holds one initializer per name or a single list to unpack.
+type Const struct {
+	Names        []token.Token
+	Initializers []expr.Expr
+}
+
+func (c Const) Accept(v Visitor) error {
+	return v.VisitConstStmt(c)
+}
+
+type Enum struct {
+	Name    token.Token
+	Members []token.Token
+}
+
+func (e Enum) Accept(v Visitor) error {
+	return v.VisitEnumStmt(e)
+}
+
 type Expr struct {
 	Expression expr.Expr
 }
@@ -34,6 +88,17 @@ func (e Expr) Accept(v Visitor) error {
 	return v.VisitExprStmt(e)
 }
 
+type ForIn struct {
+	Name     token.Token
+	In       token.Token
+	Iterable expr.Expr
+	Body     Stmt
+}
+
+func (f ForIn) Accept(v Visitor) error {
+	return v.VisitForInStmt(f)
+}
+
 type If struct {
 	Condition  expr.Expr
 	ThenBranch Stmt
@@ -44,6 +109,45 @@ func (i If) Accept(v Visitor) error {
 	return v.VisitIfStmt(i)
 }
 
+// Import imports the module at Path, binding its namespace to Name
+type Import struct {
+	Keyword token.Token
+	Path    token.Token
+	Name    token.Token
+}
+
+func (i Import) Accept(v Visitor) error {
+	return v.VisitImportStmt(i)
+}
+
+// Match is a match statement. Else is nil if there is no else case.
+type Match struct {
+	Keyword token.Token
+	Subject expr.Expr
+	Cases   []MatchCase
+	Else    Stmt
+}
+
+func (m Match) Accept(v Visitor) error {
+	return v.VisitMatchStmt(m)
+}
+
+// MatchCase is a case of a match statement. It applies if any of its
+// patterns matches and its guard, if not nil, is truthy.
+type MatchCase struct {
+	Patterns []Pattern
+	Guard    expr.Expr
+	Body     Stmt
+}
+
+// Pattern is a pattern of a match case. A binding pattern has a Binding
+// and matches any value. Otherwise Value is a literal compared for equality
+// or a range the subject has to be contained in.
+type Pattern struct {
+	Value   expr.Expr
+	Binding *token.Token
+}
+
 type Print struct {
 	Expression expr.Expr
 }
@@ -52,9 +156,33 @@ func (p Print) Accept(v Visitor) error {
 	return v.VisitPrintStmt(p)
 }
 
+type Throw struct {
+	Keyword token.Token
+	Value   expr.Expr
+}
+
+func (t Throw) Accept(v Visitor) error {
+	return v.VisitThrowStmt(t)
+}
+
+// Try is a try statement. Catch and Finally are nil if the
+// statement has no such clause.
+type Try struct {
+	Body      Block
+	CatchName token.Token
+	Catch     *Block
+	Finally   *Block
+}
+
+func (t Try) Accept(v Visitor) error {
+	return v.VisitTryStmt(t)
+}
+
+// Var declares one or more variables. Initializers holds either one
+// initializer per name, a single list to unpack, or nothing at all.
 type Var struct {
-	Name        token.Token
-	Initializer expr.Expr
+	Names        []token.Token
+	Initializers []expr.Expr
 }
 
 func (v Var) Accept(vis Visitor) error {
@@ -3,22 +3,34 @@ package token
 type TokenType int
 
 var Keywords map[string]TokenType = map[string]TokenType{
-	"and":    AND,
-	"class":  CLASS,
-	"else":   ELSE,
-	"false":  FALSE,
-	"for":    FOR,
-	"fun":    FUN,
-	"if":     IF,
-	"nil":    NIL,
-	"or":     OR,
-	"print":  PRINT,
-	"return": RETURN,
-	"super":  SUPER,
-	"this":   THIS,
-	"true":   TRUE,
-	"var":    VAR,
-	"while":  WHILE,
+	"and":     AND,
+	"as":      AS,
+	"assert":  ASSERT,
+	"case":    CASE,
+	"catch":   CATCH,
+	"class":   CLASS,
+	"const":   CONST,
+	"else":    ELSE,
+	"enum":    ENUM,
+	"false":   FALSE,
+	"finally": FINALLY,
+	"for":     FOR,
+	"fun":     FUN,
+	"if":      IF,
+	"import":  IMPORT,
+	"in":      IN,
+	"match":   MATCH,
+	"nil":     NIL,
+	"or":      OR,
+	"print":   PRINT,
+	"return":  RETURN,
+	"super":   SUPER,
+	"this":    THIS,
+	"throw":   THROW,
+	"true":    TRUE,
+	"try":     TRY,
+	"var":     VAR,
+	"while":   WHILE,
 }
 
 const (
@@ -27,6 +39,8 @@ const (
 	RIGHT_PAREN
 	LEFT_BRACE
 	RIGHT_BRACE
+	LEFT_BRACKET
+	RIGHT_BRACKET
 	COMMA
 	DOT
 	MINUS
@@ -34,37 +48,64 @@ const (
 	SEMICOLON
 	SLASH
 	STAR
+	PERCENT
+	AMPERSAND
+	PIPE
+	CARET
+	TILDE
+	QUESTION
+	COLON
 
-	// One or two character tokens
+	// One, two or three character tokens
+	HASH_LEFT_BRACE
+	DOT_DOT
+	DOT_DOT_EQUAL
+	STAR_STAR
 	BANG
 	BANG_EQUAL
 	EQUAL
 	EQUAL_EQUAL
+	FAT_ARROW
 	GREATER
 	GREATER_EQUAL
 	LESS
 	LESS_EQUAL
+	LESS_LESS
+	GREATER_GREATER
 
 	// Literals
 	IDENTIFIER
 	STRING
+	INTERPOLATION
 	NUMBER
 
 	// Keywords
 	AND
+	AS
+	ASSERT
+	CASE
+	CATCH
 	CLASS
+	CONST
 	ELSE
+	ENUM
 	FALSE
+	FINALLY
 	FUN
 	FOR
 	IF
+	IMPORT
+	IN
+	MATCH
 	NIL
 	OR
 	PRINT
 	RETURN
 	SUPER
 	THIS
+	THROW
 	TRUE
+	TRY
 	VAR
 	WHILE
 
@@ -73,9 +114,14 @@ const (
 
 func (t TokenType) String() string {
 	return [...]string{"LEFT_PAREN", "RIGHT_PAREN", "LEFT_BRACE", "RIGHT_BRACE",
-		"COMMA", "DOT", "MINUS", "PLUS", "SEMICOLON", "SLASH", "STAR", "BANG",
-		"BANG_EQUAL", "EQUAL", "EQUAL_EQUAL", "GREATER", "GREATER_EQUAL", "LESS",
-		"LESS_EQUAL", "IDENTIFIER", "STRING", "NUMBER", "AND", "CLASS", "ELSE",
-		"FALSE", "FUN", "FOR", "IF", "NIL", "OR", "PRINT", "RETURN", "SUPER",
-		"THIS", "TRUE", "VAR", "WHILE", "EOF"}[t]
+		"LEFT_BRACKET", "RIGHT_BRACKET", "COMMA", "DOT", "MINUS", "PLUS",
+		"SEMICOLON", "SLASH", "STAR", "PERCENT", "AMPERSAND", "PIPE", "CARET",
+		"TILDE", "QUESTION", "COLON", "HASH_LEFT_BRACE", "DOT_DOT",
+		"DOT_DOT_EQUAL", "STAR_STAR", "BANG", "BANG_EQUAL", "EQUAL",
+		"EQUAL_EQUAL", "FAT_ARROW", "GREATER", "GREATER_EQUAL", "LESS",
+		"LESS_EQUAL", "LESS_LESS", "GREATER_GREATER", "IDENTIFIER", "STRING",
+		"INTERPOLATION", "NUMBER", "AND", "AS", "ASSERT", "CASE", "CATCH",
+		"CLASS", "CONST", "ELSE", "ENUM", "FALSE", "FINALLY", "FUN", "FOR",
+		"IF", "IMPORT", "IN", "MATCH", "NIL", "OR", "PRINT", "RETURN", "SUPER",
+		"THIS", "THROW", "TRUE", "TRY", "VAR", "WHILE", "EOF"}[t]
 }